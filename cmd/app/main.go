@@ -2,30 +2,46 @@ package main
 
 import (
 	"flag"
-	"fmt"
+	"strings"
+	"time"
 
-	"github.com/adalrsjr1/sqlcluster/internal/services"
-	tb "github.com/adalrsjr1/sqlcluster/internal/tables"
-	sqle "github.com/dolthub/go-mysql-server"
-	"github.com/dolthub/go-mysql-server/memory"
-	"github.com/dolthub/go-mysql-server/server"
-	"github.com/dolthub/go-mysql-server/sql"
-	"github.com/dolthub/go-mysql-server/sql/information_schema"
+	"github.com/adalrsjr1/sqlcluster/pkg/clustersql"
+	tb "github.com/adalrsjr1/sqlcluster/pkg/tables"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 )
 
 var (
-	dbName  string
-	address string
-	port    int
-	log     = logrus.New().WithField("pkg", "main")
+	dbName         string
+	scratchDBName  string
+	address        string
+	port           int
+	enabledTables  string
+	disabledTables string
+	tableConfig    string
+	crdConfig      string
+	viewConfig     string
+
+	eventRetention       time.Duration
+	jobRetention         time.Duration
+	terminationRetention time.Duration
+
+	log = logrus.New().WithField("pkg", "main")
 )
 
 func init() {
 	flag.StringVar(&dbName, "dbname", "kubernetes", "name of the database")
+	flag.StringVar(&scratchDBName, "scratch-dbname", "scratch", "name of the per-session database accepting writes, none if empty")
 	flag.StringVar(&address, "address", "0.0.0.0", "address to bind the server to")
 	flag.IntVar(&port, "port", 3306, "port to listen on")
+	flag.StringVar(&enabledTables, "tables", "", "comma-separated list of tables to serve, all registered tables if empty")
+	flag.StringVar(&disabledTables, "disable-tables", "", "comma-separated list of tables not to serve")
+	flag.StringVar(&tableConfig, "table-config", "", "file listing the tables to serve or not, in addition to -tables and -disable-tables")
+	flag.StringVar(&crdConfig, "crd-config", "", "file configuring tables over custom resources")
+	flag.StringVar(&viewConfig, "view-config", "", "file configuring views created in addition to the built-in ones")
+	flag.DurationVar(&eventRetention, "event-retention", tb.DefaultEventRetention, "how long events are kept after the API server deletes them")
+	flag.DurationVar(&jobRetention, "job-retention", tb.DefaultJobRetention, "how long finished jobs are kept after the API server deletes them")
+	flag.DurationVar(&terminationRetention, "termination-retention", tb.DefaultTerminationRetention, "how long container terminations are kept after they finished")
 }
 
 func main() {
@@ -34,61 +50,23 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	if err := services.StartKubernetes(); err != nil {
-		log.WithError(err).Fatal("error to start kubernetes clients")
-	}
-
-	db := memory.NewDatabase(dbName)
-	dbProvider := sql.NewDatabaseProvider(db, information_schema.NewInformationSchemaDatabase())
-	engine := sqle.NewDefault(dbProvider)
-
-	runInformers(ctx, db)
-
-	config := server.Config{
-		Protocol: "tcp",
-		Address:  fmt.Sprintf("%s:%d", address, port),
-	}
-
-	s, err := server.NewDefaultServer(config, engine)
+	err := clustersql.Serve(ctx, clustersql.Config{
+		DBName:         dbName,
+		ScratchDBName:  scratchDBName,
+		Address:        address,
+		Port:           port,
+		Tables:         strings.Split(enabledTables, ","),
+		DisabledTables: strings.Split(disabledTables, ","),
+		TableConfig:    tableConfig,
+		Retention: map[string]time.Duration{
+			tb.EventTableName:                eventRetention,
+			tb.JobTableName:                  jobRetention,
+			tb.ContainerTerminationTableName: terminationRetention,
+		},
+		CRDConfig:  crdConfig,
+		ViewConfig: viewConfig,
+	})
 	if err != nil {
-		log.WithError(err).Fatal("error creating server")
-	}
-
-	go func() {
-		<-ctx.Done()
-		if err := s.Close(); err != nil {
-			log.WithError(err).Error("error stopping server")
-		} else {
-			log.Info("server stopped")
-		}
-	}()
-
-	if err = s.Start(); err != nil {
-		log.WithError(err).Fatal("error starting server")
-	}
-
-}
-
-func runInformers(ctx context.Context, db *memory.Database) {
-	tables := []struct {
-		name      string
-		startFunc func(context.Context, *memory.Database)
-	}{
-		{tb.AffinityTableName, tb.StartAffinityInformer},
-		{tb.NodeAffinityTableName, tb.StartNodeAffinityInformer},
-		{tb.NodeMetricsTableName, tb.StartNodeMetricsInformer},
-		{tb.PodMetricsTableName, tb.StartPodMetricsInformer},
-		{tb.PodTableName, tb.StartPodInformer},
-		{tb.EndpointTableName, tb.StartEndpointInformer},
-		{tb.NodeTableName, tb.StartNodeInformer},
-		{tb.ContainerTableName, tb.StartContainerInformer},
-		{tb.TrafficTableName, tb.StartTrafficInformer},
-	}
-
-	for _, t := range tables {
-		tableCtx := sql.NewContext(ctx)
-		log.Infof("starting informer: %s", t.name)
-		go t.startFunc(tableCtx, db)
+		log.WithError(err).Fatal("error serving tables")
 	}
-
 }
@@ -0,0 +1,22 @@
+// Package config reads the configuration files of the server.
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+// LoadYAML decodes a YAML or JSON file into out, rejecting fields out does
+// not declare so that typos are reported rather than ignored.
+func LoadYAML(path string, out interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := yaml.UnmarshalStrict(data, out); err != nil {
+		return fmt.Errorf("cannot parse %s: %w", path, err)
+	}
+	return nil
+}
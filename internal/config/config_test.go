@@ -0,0 +1,96 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadYAML(t *testing.T) {
+	type item struct {
+		Name string `json:"name"`
+		Size int    `json:"size"`
+	}
+	type items struct {
+		Items []item `json:"items"`
+	}
+
+	tests := []struct {
+		name    string
+		config  string
+		want    items
+		wantErr string
+	}{
+		{
+			name: "yaml",
+			config: `
+items:
+- name: a
+  size: 1
+`,
+			want: items{Items: []item{{Name: "a", Size: 1}}},
+		},
+		{
+			name:   "json",
+			config: `{"items": [{"name": "a"}]}`,
+			want:   items{Items: []item{{Name: "a"}}},
+		},
+		{
+			name:   "empty",
+			config: "items: []\n",
+			want:   items{Items: []item{}},
+		},
+		{
+			name: "unknown field",
+			config: `
+items:
+- name: a
+  kind: b
+`,
+			wantErr: "unknown field",
+		},
+		{
+			name:    "wrong type",
+			config:  "items:\n- size: large\n",
+			wantErr: "cannot parse",
+		},
+		{
+			name:    "not yaml",
+			config:  "items: [",
+			wantErr: "cannot parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.config), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got := items{}
+			err := LoadYAML(path, &got)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadYAML() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadYAML() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadYAML() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadYAMLMissingFile(t *testing.T) {
+	err := LoadYAML(filepath.Join(t.TempDir(), "missing.yaml"), &struct{}{})
+	if !os.IsNotExist(err) {
+		t.Errorf("LoadYAML() error = %v, want not exist", err)
+	}
+}
@@ -0,0 +1,121 @@
+package functions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// function is a scalar function evaluated over the values of its arguments.
+// It returns NULL as soon as an argument is NULL.
+type function struct {
+	name             string
+	description      string
+	returnType       sql.Type
+	nondeterministic bool
+	eval             func(args []interface{}) (interface{}, error)
+	args             []sql.Expression
+}
+
+var _ sql.FunctionExpression = (*function)(nil)
+var _ sql.NonDeterministicExpression = (*function)(nil)
+
+func newFunction(name, description string, returnType sql.Type, arity int, nondeterministic bool, eval func(args []interface{}) (interface{}, error)) sql.Function {
+	return sql.FunctionN{
+		Name: name,
+		Fn: func(args ...sql.Expression) (sql.Expression, error) {
+			if len(args) != arity {
+				return nil, sql.ErrInvalidArgumentNumber.New(name, arity, len(args))
+			}
+			return &function{
+				name:             name,
+				description:      description,
+				returnType:       returnType,
+				nondeterministic: nondeterministic,
+				eval:             eval,
+				args:             args,
+			}, nil
+		},
+	}
+}
+
+// FunctionName implements sql.FunctionExpression.
+func (f *function) FunctionName() string {
+	return f.name
+}
+
+// Description implements sql.FunctionExpression.
+func (f *function) Description() string {
+	return f.description
+}
+
+// IsNonDeterministic implements sql.NonDeterministicExpression.
+func (f *function) IsNonDeterministic() bool {
+	return f.nondeterministic
+}
+
+// Resolved implements sql.Expression.
+func (f *function) Resolved() bool {
+	for _, arg := range f.args {
+		if !arg.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements sql.Expression.
+func (f *function) String() string {
+	args := make([]string, len(f.args))
+	for i, arg := range f.args {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("%s(%s)", strings.ToUpper(f.name), strings.Join(args, ", "))
+}
+
+// Type implements sql.Expression.
+func (f *function) Type() sql.Type {
+	return f.returnType
+}
+
+// IsNullable implements sql.Expression.
+func (f *function) IsNullable() bool {
+	return true
+}
+
+// Children implements sql.Expression.
+func (f *function) Children() []sql.Expression {
+	return f.args
+}
+
+// WithChildren implements sql.Expression.
+func (f *function) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != len(f.args) {
+		return nil, sql.ErrInvalidChildrenNumber.New(f, len(children), len(f.args))
+	}
+	nf := *f
+	nf.args = children
+	return &nf, nil
+}
+
+// Eval implements sql.Expression.
+func (f *function) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	values := make([]interface{}, len(f.args))
+	for i, arg := range f.args {
+		value, err := arg.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			return nil, nil
+		}
+		values[i] = value
+	}
+
+	result, err := f.eval(values)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", strings.ToUpper(f.name), err)
+	}
+	return result, nil
+}
@@ -0,0 +1,171 @@
+// Package functions implements SQL functions giving queries the semantics
+// Kubernetes applies to quantities, label selectors and images.
+package functions
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// Functions lists the functions to register with the engine.
+var Functions = []sql.Function{
+	newFunction("k8s_quantity", "returns the value of a Kubernetes quantity in its base unit, such as bytes.", sql.Int64, 1, false, k8sQuantity),
+	newFunction("k8s_cpu", "returns a Kubernetes CPU quantity in millicores.", sql.Int64, 1, false, k8sCPU),
+	newFunction("format_bytes", "formats a number of bytes with binary units, such as 1.5Gi.", sql.Text, 1, false, formatBytes),
+	newFunction("label_selector_matches", "returns whether a labels JSON object matches a label selector.", sql.Boolean, 2, false, labelSelectorMatches),
+	newFunction("age", "returns the time elapsed since a date the way kubectl does, such as 3d4h.", sql.Text, 1, true, age),
+	newFunction("image_repo", "returns the repository of a container image, without tag nor digest.", sql.Text, 1, false, imageRepo),
+	newFunction("image_tag", "returns the tag of a container image, latest when untagged.", sql.Text, 1, false, imageTag),
+}
+
+func k8sQuantity(args []interface{}) (interface{}, error) {
+	q, err := parseQuantity(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return q.Value(), nil
+}
+
+func k8sCPU(args []interface{}) (interface{}, error) {
+	q, err := parseQuantity(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return q.MilliValue(), nil
+}
+
+func parseQuantity(arg interface{}) (resource.Quantity, error) {
+	s, err := stringArg(arg)
+	if err != nil {
+		return resource.Quantity{}, err
+	}
+	return resource.ParseQuantity(strings.TrimSpace(s))
+}
+
+var byteUnits = []string{"", "Ki", "Mi", "Gi", "Ti", "Pi", "Ei"}
+
+func formatBytes(args []interface{}) (interface{}, error) {
+	value, err := sql.Float64.Convert(args[0])
+	if err != nil {
+		return nil, err
+	}
+	bytes := value.(float64)
+
+	unit := 0
+	for math.Abs(bytes) >= 1024 && unit < len(byteUnits)-1 {
+		bytes /= 1024
+		unit++
+	}
+	return strconv.FormatFloat(math.Round(bytes*10)/10, 'f', -1, 64) + byteUnits[unit], nil
+}
+
+// labelSelectorMatches parses the selector with the syntax kubectl accepts,
+// e.g. app in (a,b),tier!=db.
+func labelSelectorMatches(args []interface{}) (interface{}, error) {
+	set, err := labelsArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	s, err := stringArg(args[1])
+	if err != nil {
+		return nil, err
+	}
+	selector, err := labels.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if selector.Matches(set) {
+		return int8(1), nil
+	}
+	return int8(0), nil
+}
+
+func labelsArg(arg interface{}) (labels.Set, error) {
+	var value interface{}
+	switch v := arg.(type) {
+	case sql.JSONDocument:
+		value = v.Val
+	case string:
+		if err := json.Unmarshal([]byte(v), &value); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("expected labels as a JSON object but got %T", arg)
+	}
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected labels as a JSON object but got %T", value)
+	}
+	set := labels.Set{}
+	for key, v := range object {
+		set[key] = fmt.Sprint(v)
+	}
+	return set, nil
+}
+
+func age(args []interface{}) (interface{}, error) {
+	value, err := sql.Datetime.Convert(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return duration.HumanDuration(time.Since(value.(time.Time))), nil
+}
+
+// splitImage splits an image reference into its repository, tag and digest.
+// A colon only starts a tag after the last slash, as it may also separate
+// the port of the registry.
+func splitImage(image string) (repo, tag, digest string) {
+	repo = image
+	if i := strings.Index(repo, "@"); i >= 0 {
+		repo, digest = repo[:i], repo[i+1:]
+	}
+	if i := strings.LastIndex(repo, ":"); i > strings.LastIndex(repo, "/") {
+		repo, tag = repo[:i], repo[i+1:]
+	}
+	return repo, tag, digest
+}
+
+func imageRepo(args []interface{}) (interface{}, error) {
+	image, err := stringArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	repo, _, _ := splitImage(image)
+	return repo, nil
+}
+
+// imageTag returns NULL for images pinned by digest only, as they have no
+// tag.
+func imageTag(args []interface{}) (interface{}, error) {
+	image, err := stringArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	_, tag, digest := splitImage(image)
+	switch {
+	case tag != "":
+		return tag, nil
+	case digest != "":
+		return nil, nil
+	default:
+		return "latest", nil
+	}
+}
+
+func stringArg(arg interface{}) (string, error) {
+	value, err := sql.LongText.Convert(arg)
+	if err != nil {
+		return "", err
+	}
+	return value.(string), nil
+}
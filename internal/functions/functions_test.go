@@ -0,0 +1,91 @@
+package functions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestFunctions(t *testing.T) {
+	tests := []struct {
+		name     string
+		function string
+		args     []interface{}
+		want     interface{}
+		wantErr  bool
+	}{
+		{name: "quantity", function: "k8s_quantity", args: []interface{}{"128Mi"}, want: int64(128 << 20)},
+		{name: "quantity decimal", function: "k8s_quantity", args: []interface{}{"1G"}, want: int64(1000000000)},
+		{name: "quantity rounded up", function: "k8s_quantity", args: []interface{}{"500m"}, want: int64(1)},
+		{name: "quantity spaces", function: "k8s_quantity", args: []interface{}{" 2Ki "}, want: int64(2048)},
+		{name: "invalid quantity", function: "k8s_quantity", args: []interface{}{"12 apples"}, wantErr: true},
+		{name: "null quantity", function: "k8s_quantity", args: []interface{}{nil}, want: nil},
+		{name: "cpu cores", function: "k8s_cpu", args: []interface{}{"2"}, want: int64(2000)},
+		{name: "cpu fraction", function: "k8s_cpu", args: []interface{}{"0.5"}, want: int64(500)},
+		{name: "milli cpu", function: "k8s_cpu", args: []interface{}{"250m"}, want: int64(250)},
+		{name: "invalid cpu", function: "k8s_cpu", args: []interface{}{"m250"}, wantErr: true},
+		{name: "bytes", function: "format_bytes", args: []interface{}{int64(512)}, want: "512"},
+		{name: "kibibytes", function: "format_bytes", args: []interface{}{int64(1536)}, want: "1.5Ki"},
+		{name: "gibibytes", function: "format_bytes", args: []interface{}{int64(3 << 30)}, want: "3Gi"},
+		{name: "negative bytes", function: "format_bytes", args: []interface{}{int64(-2048)}, want: "-2Ki"},
+		{name: "selector matches", function: "label_selector_matches", args: []interface{}{`{"app":"web","tier":"frontend"}`, "app in (web,api),tier!=db"}, want: int8(1)},
+		{name: "selector does not match", function: "label_selector_matches", args: []interface{}{sql.JSONDocument{Val: map[string]interface{}{"app": "db"}}, "app=web"}, want: int8(0)},
+		{name: "selector exists", function: "label_selector_matches", args: []interface{}{`{"app":"web"}`, "!tier"}, want: int8(1)},
+		{name: "labels not an object", function: "label_selector_matches", args: []interface{}{`["app"]`, "app"}, wantErr: true},
+		{name: "invalid selector", function: "label_selector_matches", args: []interface{}{`{"app":"web"}`, "app in web"}, wantErr: true},
+		{name: "null selector", function: "label_selector_matches", args: []interface{}{`{"app":"web"}`, nil}, want: nil},
+		{name: "repo", function: "image_repo", args: []interface{}{"nginx:1.25"}, want: "nginx"},
+		{name: "repo registry port", function: "image_repo", args: []interface{}{"registry.local:5000/team/app"}, want: "registry.local:5000/team/app"},
+		{name: "repo registry port tag", function: "image_repo", args: []interface{}{"registry.local:5000/team/app:v2"}, want: "registry.local:5000/team/app"},
+		{name: "repo digest", function: "image_repo", args: []interface{}{"nginx@sha256:0123abcd"}, want: "nginx"},
+		{name: "tag", function: "image_tag", args: []interface{}{"nginx:1.25"}, want: "1.25"},
+		{name: "tag untagged", function: "image_tag", args: []interface{}{"nginx"}, want: "latest"},
+		{name: "tag registry port", function: "image_tag", args: []interface{}{"registry.local:5000/team/app"}, want: "latest"},
+		{name: "tag registry port tag", function: "image_tag", args: []interface{}{"registry.local:5000/team/app:v2"}, want: "v2"},
+		{name: "tag digest only", function: "image_tag", args: []interface{}{"nginx@sha256:0123abcd"}, want: nil},
+		{name: "tag and digest", function: "image_tag", args: []interface{}{"nginx:1.25@sha256:0123abcd"}, want: "1.25"},
+		{name: "age", function: "age", args: []interface{}{time.Now().Add(-(74*time.Hour + 5*time.Minute))}, want: "3d2h"},
+		{name: "invalid age", function: "age", args: []interface{}{"yesterday"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := make([]sql.Expression, len(tt.args))
+			for i, arg := range tt.args {
+				args[i] = expression.NewLiteral(arg, nil)
+			}
+			f, err := registered(t, tt.function).NewInstance(args)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := f.Eval(sql.NewEmptyContext(), nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("%s() error = %v, want error %v", tt.function, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("%s() = %#v, want %#v", tt.function, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFunctionArity(t *testing.T) {
+	_, err := registered(t, "image_tag").NewInstance(nil)
+	if err == nil {
+		t.Error("image_tag() without arguments should fail")
+	}
+}
+
+func registered(t *testing.T, name string) sql.Function {
+	t.Helper()
+	for _, f := range Functions {
+		if f.FunctionName() == name {
+			return f
+		}
+	}
+	t.Fatalf("function %s not registered", name)
+	return nil
+}
@@ -0,0 +1,102 @@
+// Package readonly keeps clients from writing to the databases mirroring the
+// cluster, as their tables are projected from informer caches.
+package readonly
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+	"github.com/dolthub/vitess/go/mysql"
+)
+
+// ruleId identifies the rule rejecting writes, out of the range used by the
+// default rules.
+const ruleId analyzer.RuleId = 1 << 16
+
+// NewAnalyzer returns the default analyzer, rejecting the statements that
+// write to the given databases. Statements are checked before being
+// analyzed, as analysis may fail with less helpful errors, e.g. on tables not
+// supporting INSERT, or prune the tables written by a statement, e.g. on a
+// DELETE whose filter is always false.
+func NewAnalyzer(provider sql.DatabaseProvider, databases ...string) *analyzer.Analyzer {
+	readOnly := map[string]struct{}{}
+	for _, name := range databases {
+		readOnly[strings.ToLower(name)] = struct{}{}
+	}
+	return analyzer.NewBuilder(provider).AddPreAnalyzeRule(ruleId, rejectWrites(readOnly)).Build()
+}
+
+// errReadOnly is the error MySQL returns to writes when running with
+// --read-only.
+func errReadOnly(database string) error {
+	return mysql.NewSQLError(mysql.EROptionPreventsStatement, mysql.SSUnknownSQLState,
+		"database %s is read-only, it mirrors the state of the cluster", database)
+}
+
+func rejectWrites(readOnly map[string]struct{}) analyzer.RuleFunc {
+	return func(ctx *sql.Context, a *analyzer.Analyzer, n sql.Node, scope *analyzer.Scope, sel analyzer.RuleSelector) (sql.Node, transform.TreeIdentity, error) {
+		if database := writtenDatabase(ctx, n, readOnly); database != "" {
+			return nil, transform.SameTree, errReadOnly(database)
+		}
+		return n, transform.SameTree, nil
+	}
+}
+
+// writtenDatabase returns the read-only database a parsed statement writes
+// to, if any. Statements may still read from read-only databases, e.g. to
+// copy rows to a table of another database.
+func writtenDatabase(ctx *sql.Context, n sql.Node, readOnly map[string]struct{}) string {
+	written := ""
+	check := func(database string) {
+		if database == "" {
+			database = ctx.GetCurrentDatabase()
+		}
+		if _, ok := readOnly[strings.ToLower(database)]; ok {
+			written = database
+		}
+	}
+	checkTables := func(node sql.Node) bool {
+		switch table := node.(type) {
+		case *plan.UnresolvedTable:
+			check(table.Database())
+		case *plan.ResolvedTable:
+			check(table.Database.Name())
+		}
+		return written == ""
+	}
+
+	switch n := n.(type) {
+	case *plan.InsertInto:
+		check(n.Database().Name())
+		transform.Inspect(n.Destination, checkTables)
+	case *plan.DeleteFrom, *plan.Update, *plan.Truncate:
+		transform.Inspect(n, checkTables)
+	case *plan.CreateTable:
+		check(n.Database().Name())
+	case *plan.CreateView:
+		check(n.Database().Name())
+	case *plan.DropDB:
+		// DropDB does not expose the name of the database it drops
+		check(reflect.ValueOf(n).Elem().FieldByName("dbName").String())
+	case *plan.LockTables:
+		for _, lock := range n.Locks {
+			if lock.Write {
+				transform.Inspect(lock.Table, checkTables)
+			}
+		}
+	default:
+		if plan.IsDDLNode(n) {
+			transform.Inspect(n, func(node sql.Node) bool {
+				if databaser, ok := node.(sql.Databaser); ok && databaser.Database() != nil {
+					check(databaser.Database().Name())
+				}
+				return checkTables(node)
+			})
+		}
+	}
+	return written
+}
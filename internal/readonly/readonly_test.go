@@ -0,0 +1,187 @@
+package readonly_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adalrsjr1/sqlcluster/internal/readonly"
+	"github.com/adalrsjr1/sqlcluster/internal/scratch"
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+)
+
+// writes run in order, each one relying on the previous ones when they
+// succeed.
+var writes = []string{
+	"CREATE TABLE replica (id INT PRIMARY KEY, name TEXT)",
+	"INSERT INTO pod VALUES (2, 'db')",
+	"INSERT INTO pod SELECT id + 10, name FROM kubernetes.pod",
+	"UPDATE pod SET name = 'api' WHERE id = 2",
+	"DELETE FROM pod WHERE 1 = 0",
+	"DELETE FROM pod WHERE id = 2",
+	"ALTER TABLE pod ADD COLUMN phase TEXT",
+	"TRUNCATE pod",
+	"CREATE VIEW pod_names AS SELECT name FROM pod",
+	"LOCK TABLES pod WRITE",
+	"DROP TABLE pod",
+}
+
+func TestReadOnly(t *testing.T) {
+	tests := []struct {
+		database string
+		readOnly bool
+	}{
+		{database: "kubernetes", readOnly: true},
+		{database: "scratch", readOnly: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.database, func(t *testing.T) {
+			engine, ctx := newEngine(t)
+			ctx.SetCurrentDatabase(tt.database)
+			if !tt.readOnly {
+				query(t, engine, ctx, "CREATE TABLE pod (id INT PRIMARY KEY, name TEXT)")
+				query(t, engine, ctx, "INSERT INTO pod VALUES (1, 'web')")
+			}
+
+			for _, statement := range writes {
+				_, err := run(engine, ctx, statement)
+				if !tt.readOnly {
+					if err != nil {
+						t.Errorf("%s: %v", statement, err)
+					}
+					continue
+				}
+				if err == nil {
+					t.Errorf("%s: writing to a read-only database should fail", statement)
+				} else if code := sql.CastSQLError(err).Num; code != mysql.EROptionPreventsStatement {
+					t.Errorf("%s: error %d %v, want error %d", statement, code, err, mysql.EROptionPreventsStatement)
+				}
+			}
+		})
+	}
+}
+
+func TestReadOnlyQualified(t *testing.T) {
+	engine, ctx := newEngine(t)
+	ctx.SetCurrentDatabase("scratch")
+
+	_, err := run(engine, ctx, "INSERT INTO kubernetes.pod VALUES (2, 'db')")
+	if err == nil || sql.CastSQLError(err).Num != mysql.EROptionPreventsStatement {
+		t.Errorf("writing to a qualified read-only table: error %v, want error %d", err, mysql.EROptionPreventsStatement)
+	}
+
+	query(t, engine, ctx, "CREATE TABLE pod AS SELECT * FROM kubernetes.pod")
+	if rows := query(t, engine, ctx, "SELECT name FROM pod"); len(rows) != 1 || rows[0][0] != "web" {
+		t.Errorf("copied rows = %v, want [[web]]", rows)
+	}
+	if rows := query(t, engine, ctx, "SELECT name FROM kubernetes.pod"); len(rows) != 1 {
+		t.Errorf("read-only rows = %v, want [[web]]", rows)
+	}
+}
+
+func TestReadOnlyStatements(t *testing.T) {
+	tests := []struct {
+		statement string
+		readOnly  bool
+	}{
+		{statement: "DROP DATABASE kubernetes", readOnly: true},
+		{statement: "DROP DATABASE IF EXISTS KUBERNETES", readOnly: true},
+		{statement: "LOCK TABLES pod WRITE", readOnly: true},
+		{statement: "LOCK TABLES scratch.replica READ, kubernetes.pod WRITE", readOnly: true},
+		{statement: "LOCK TABLES pod READ", readOnly: false},
+		{statement: "UNLOCK TABLES", readOnly: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			engine, ctx := newEngine(t)
+			_, err := run(engine, ctx, tt.statement)
+			if !tt.readOnly {
+				if err != nil {
+					t.Errorf("%s: %v", tt.statement, err)
+				}
+				return
+			}
+			if err == nil || sql.CastSQLError(err).Num != mysql.EROptionPreventsStatement {
+				t.Errorf("%s: error %v, want error %d", tt.statement, err, mysql.EROptionPreventsStatement)
+			}
+		})
+	}
+}
+
+// TestScratchSessions checks the tables and views of a scratch database are
+// only seen by the session creating them.
+func TestScratchSessions(t *testing.T) {
+	engine, ctx := newEngine(t)
+	other := newContext(t, 2)
+
+	ctx.SetCurrentDatabase("scratch")
+	query(t, engine, ctx, "CREATE TABLE replica AS SELECT * FROM kubernetes.pod")
+	query(t, engine, ctx, "CREATE VIEW replica_names AS SELECT name FROM replica")
+	if rows := query(t, engine, ctx, "SELECT name FROM replica_names"); len(rows) != 1 {
+		t.Errorf("view rows = %v, want [[web]]", rows)
+	}
+
+	other.SetCurrentDatabase("scratch")
+	for _, statement := range []string{"SELECT name FROM replica_names", "SELECT name FROM replica"} {
+		if _, err := run(engine, other, statement); err == nil {
+			t.Errorf("%s: another session should not see the scratch tables and views", statement)
+		}
+	}
+
+	query(t, engine, other, "CREATE TABLE replica (id INT PRIMARY KEY)")
+	if rows := query(t, engine, ctx, "SELECT name FROM replica"); len(rows) != 1 {
+		t.Errorf("rows = %v, want [[web]]", rows)
+	}
+}
+
+func newEngine(t *testing.T) (*sqle.Engine, *sql.Context) {
+	t.Helper()
+
+	db := memory.NewDatabase("kubernetes")
+	table := memory.NewTable("pod", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "id", Type: sql.Int64, Source: "pod", PrimaryKey: true},
+		{Name: "name", Type: sql.Text, Source: "pod"},
+	}), db.GetForeignKeyCollection())
+	db.AddTable("pod", table)
+
+	provider := sql.NewDatabaseProvider(db, scratch.NewDatabase("scratch"))
+	engine := sqle.New(readonly.NewAnalyzer(provider, "kubernetes"), nil)
+
+	ctx := newContext(t, 1)
+	ctx.SetCurrentDatabase("kubernetes")
+	if err := table.Insert(ctx, sql.NewRow(int64(1), "web")); err != nil {
+		t.Fatal(err)
+	}
+	return engine, ctx
+}
+
+// newContext opens a client session, as the server does for a connection.
+func newContext(t *testing.T, connectionID uint32) *sql.Context {
+	t.Helper()
+	session, err := scratch.NewSession(context.Background(), &mysql.Conn{ConnectionID: connectionID}, "localhost:3306")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sql.NewContext(context.Background(), sql.WithSession(session))
+}
+
+func run(engine *sqle.Engine, ctx *sql.Context, statement string) ([]sql.Row, error) {
+	schema, iter, err := engine.Query(ctx, statement)
+	if err != nil {
+		return nil, err
+	}
+	return sql.RowIterToRows(ctx, schema, iter)
+}
+
+func query(t *testing.T, engine *sqle.Engine, ctx *sql.Context, statement string) []sql.Row {
+	t.Helper()
+	rows, err := run(engine, ctx, statement)
+	if err != nil {
+		t.Fatalf("%s: %v", statement, err)
+	}
+	return rows
+}
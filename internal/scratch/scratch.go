@@ -0,0 +1,135 @@
+// Package scratch implements a database clients can write to. Its tables and
+// views belong to the session creating them, so clients can stage results
+// without seeing nor altering each other's.
+package scratch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+)
+
+// Session is a client session holding the tables of its scratch databases.
+type Session struct {
+	*sql.BaseSession
+	mu        sync.Mutex
+	databases map[string]*memory.BaseDatabase
+}
+
+var _ server.SessionBuilder = NewSession
+
+// NewSession builds the default session of a connection, able to hold
+// scratch tables.
+func NewSession(ctx context.Context, conn *mysql.Conn, addr string) (sql.Session, error) {
+	session, err := server.DefaultSessionBuilder(ctx, conn, addr)
+	if err != nil {
+		return nil, err
+	}
+	base, ok := session.(*sql.BaseSession)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for session, expected *sql.BaseSession but got %T", session)
+	}
+	return &Session{BaseSession: base, databases: map[string]*memory.BaseDatabase{}}, nil
+}
+
+// database returns the tables of the session in a scratch database. Views
+// need no storage here, as databases without views register them in the
+// view registry of the session.
+func (s *Session) database(name string) *memory.BaseDatabase {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	db, ok := s.databases[name]
+	if !ok {
+		db = memory.NewViewlessDatabase(name)
+		s.databases[name] = db
+	}
+	return db
+}
+
+// Database is a scratch database, delegating to the tables of the session
+// running each query.
+type Database struct {
+	name string
+}
+
+var _ sql.Database = (*Database)(nil)
+var _ sql.TableCreator = (*Database)(nil)
+var _ sql.TemporaryTableCreator = (*Database)(nil)
+var _ sql.TableDropper = (*Database)(nil)
+var _ sql.TableRenamer = (*Database)(nil)
+
+// NewDatabase creates a scratch database.
+func NewDatabase(name string) *Database {
+	return &Database{name: name}
+}
+
+func (d *Database) session(ctx *sql.Context) (*memory.BaseDatabase, error) {
+	session, ok := ctx.Session.(*Session)
+	if !ok {
+		return nil, fmt.Errorf("database %s is only available to client sessions", d.name)
+	}
+	return session.database(d.name), nil
+}
+
+// Name implements sql.Nameable.
+func (d *Database) Name() string {
+	return d.name
+}
+
+// GetTableInsensitive implements sql.Database. Contexts without a client
+// session, such as the ones of the server itself, see an empty database.
+func (d *Database) GetTableInsensitive(ctx *sql.Context, name string) (sql.Table, bool, error) {
+	db, err := d.session(ctx)
+	if err != nil {
+		return nil, false, nil
+	}
+	return db.GetTableInsensitive(ctx, name)
+}
+
+// GetTableNames implements sql.Database.
+func (d *Database) GetTableNames(ctx *sql.Context) ([]string, error) {
+	db, err := d.session(ctx)
+	if err != nil {
+		return nil, nil
+	}
+	return db.GetTableNames(ctx)
+}
+
+// CreateTable implements sql.TableCreator.
+func (d *Database) CreateTable(ctx *sql.Context, name string, schema sql.PrimaryKeySchema, collation sql.CollationID) error {
+	db, err := d.session(ctx)
+	if err != nil {
+		return err
+	}
+	return db.CreateTable(ctx, name, schema, collation)
+}
+
+// CreateTemporaryTable implements sql.TemporaryTableCreator. Every scratch
+// table is already dropped with its session.
+func (d *Database) CreateTemporaryTable(ctx *sql.Context, name string, schema sql.PrimaryKeySchema, collation sql.CollationID) error {
+	return d.CreateTable(ctx, name, schema, collation)
+}
+
+// DropTable implements sql.TableDropper.
+func (d *Database) DropTable(ctx *sql.Context, name string) error {
+	db, err := d.session(ctx)
+	if err != nil {
+		return err
+	}
+	return db.DropTable(ctx, name)
+}
+
+// RenameTable implements sql.TableRenamer.
+func (d *Database) RenameTable(ctx *sql.Context, oldName, newName string) error {
+	db, err := d.session(ctx)
+	if err != nil {
+		return err
+	}
+	return db.RenameTable(ctx, oldName, newName)
+}
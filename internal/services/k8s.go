@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -13,9 +14,10 @@ import (
 )
 
 var (
-	Clientset   kubernetes.Interface
-	ClientsetVS *metricsv.Clientset
-	log         = logrus.New().WithField("pkg", "services")
+	Clientset     kubernetes.Interface
+	ClientsetVS   *metricsv.Clientset
+	DynamicClient dynamic.Interface
+	log           = logrus.New().WithField("pkg", "services")
 )
 
 func StartKubernetes() error {
@@ -50,5 +52,10 @@ func StartKubernetes() error {
 		return fmt.Errorf("error getting kubernetes metricvs: %w", err)
 	}
 
+	DynamicClient, err = dynamic.NewForConfig(kubeConfig)
+	if err != nil {
+		return fmt.Errorf("error getting kubernetes dynamic client: %w", err)
+	}
+
 	return nil
 }
@@ -0,0 +1,148 @@
+// Package views defines the SQL views created over the tables at startup.
+package views
+
+import (
+	"fmt"
+
+	"github.com/adalrsjr1/sqlcluster/internal/config"
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/parse"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.New().WithField("pkg", "views")
+
+// View is a named SELECT statement.
+type View struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+type viewConfig struct {
+	Views []View `json:"views"`
+}
+
+// activePods restricts pods to the ones holding resources on their node.
+const activePods = `p.deleted_at IS NULL AND p.phase NOT IN ('Succeeded', 'Failed')`
+
+// Views lists the views created by default. Resources are in bytes and
+// millicores, and ratios are NULL when there is nothing to divide by.
+var Views = []View{
+	{
+		Name: "pod_utilization",
+		Query: `SELECT c.namespace, c.pod, c.container,
+	m.usage_cpu, c.request_cpu, c.limit_cpu,
+	m.usage_cpu / NULLIF(c.request_cpu, 0) AS cpu_request_ratio,
+	m.usage_cpu / NULLIF(c.limit_cpu, 0) AS cpu_limit_ratio,
+	m.usage_memory, c.request_memory, c.limit_memory,
+	m.usage_memory / NULLIF(c.request_memory, 0) AS memory_request_ratio,
+	m.usage_memory / NULLIF(c.limit_memory, 0) AS memory_limit_ratio
+FROM container c
+JOIN pod_metrics m ON m.namespace = c.namespace AND m.pod = c.pod AND m.container = c.container`,
+	},
+	{
+		Name: "node_allocation",
+		Query: `SELECT n.name AS node, COALESCE(r.pods, 0) AS pods,
+	n.free_cpu AS allocatable_cpu, COALESCE(r.request_cpu, 0) AS request_cpu, COALESCE(r.limit_cpu, 0) AS limit_cpu,
+	COALESCE(r.request_cpu, 0) / NULLIF(n.free_cpu, 0) AS cpu_request_ratio,
+	n.free_memory AS allocatable_memory, COALESCE(r.request_memory, 0) AS request_memory, COALESCE(r.limit_memory, 0) AS limit_memory,
+	COALESCE(r.request_memory, 0) / NULLIF(n.free_memory, 0) AS memory_request_ratio
+FROM node n
+LEFT JOIN (
+	SELECT p.node, COUNT(DISTINCT p.uid) AS pods,
+		SUM(c.request_cpu) AS request_cpu, SUM(c.limit_cpu) AS limit_cpu,
+		SUM(c.request_memory) AS request_memory, SUM(c.limit_memory) AS limit_memory
+	FROM pod p
+	JOIN container c ON c.pod_uid = p.uid
+	WHERE ` + activePods + `
+	GROUP BY p.node
+) r ON r.node = n.name`,
+	},
+	{
+		Name: "node_pressure",
+		Query: `SELECT n.name AS node,
+	m.usage_cpu, n.free_cpu AS allocatable_cpu, n.capacity_cpu,
+	m.usage_cpu / NULLIF(n.free_cpu, 0) AS cpu_allocatable_ratio,
+	m.usage_cpu / NULLIF(n.capacity_cpu, 0) AS cpu_capacity_ratio,
+	m.usage_memory, n.free_memory AS allocatable_memory, n.capacity_memory,
+	m.usage_memory / NULLIF(n.free_memory, 0) AS memory_allocatable_ratio,
+	m.usage_memory / NULLIF(n.capacity_memory, 0) AS memory_capacity_ratio
+FROM node n
+JOIN node_metrics m ON m.name = n.name`,
+	},
+	{
+		Name: "namespace_resource_summary",
+		Query: `SELECT r.namespace, r.pods, r.containers,
+	r.request_cpu, r.limit_cpu, u.usage_cpu,
+	r.request_memory, r.limit_memory, u.usage_memory
+FROM (
+	SELECT p.namespace, COUNT(DISTINCT p.uid) AS pods, COUNT(*) AS containers,
+		SUM(c.request_cpu) AS request_cpu, SUM(c.limit_cpu) AS limit_cpu,
+		SUM(c.request_memory) AS request_memory, SUM(c.limit_memory) AS limit_memory
+	FROM pod p
+	JOIN container c ON c.pod_uid = p.uid
+	WHERE ` + activePods + `
+	GROUP BY p.namespace
+) r
+LEFT JOIN (
+	SELECT namespace, SUM(usage_cpu) AS usage_cpu, SUM(usage_memory) AS usage_memory
+	FROM pod_metrics
+	GROUP BY namespace
+) u ON u.namespace = r.namespace`,
+	},
+}
+
+// Load reads the views configured in a YAML or JSON file.
+func Load(path string) ([]View, error) {
+	views := viewConfig{}
+	if err := config.LoadYAML(path, &views); err != nil {
+		return nil, err
+	}
+	return views.Views, nil
+}
+
+// Create adds the views to the database. Views over tables the server does
+// not serve, such as the metrics tables without a metrics server, are
+// skipped.
+func Create(engine *sqle.Engine, db *memory.Database, views []View) {
+	ctx := sql.NewEmptyContext()
+	ctx.SetCurrentDatabase(db.Name())
+
+	for _, view := range views {
+		if err := create(ctx, engine, db, view); err != nil {
+			log.Warnf("view %s skipped: %v", view.Name, err)
+			continue
+		}
+		log.Infof("view [%s] created", view.Name)
+	}
+}
+
+func create(ctx *sql.Context, engine *sqle.Engine, db *memory.Database, view View) error {
+	if view.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if _, ok, err := db.GetTableInsensitive(ctx, view.Name); err != nil {
+		return err
+	} else if ok {
+		return sql.ErrTableAlreadyExists.New(view.Name)
+	}
+
+	// the parser only accepts a SELECT statement as the definition of a view
+	if _, err := parse.Parse(ctx, fmt.Sprintf("CREATE VIEW `%s` AS %s", view.Name, view.Query)); err != nil {
+		return err
+	}
+	if err := db.CreateView(ctx, view.Name, view.Query); err != nil {
+		return err
+	}
+
+	// analyzing the view resolves its tables and columns
+	if _, err := engine.AnalyzeQuery(ctx, fmt.Sprintf("SELECT * FROM `%s`", view.Name)); err != nil {
+		if dropErr := db.DropView(ctx, view.Name); dropErr != nil {
+			return dropErr
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package views
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adalrsjr1/sqlcluster/pkg/tables"
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestCreate(t *testing.T) {
+	tests := []struct {
+		name       string
+		view       View
+		wantCreate bool
+	}{
+		{name: "view", view: View{Name: "pod_names", Query: "SELECT name FROM pod"}, wantCreate: true},
+		{name: "missing table", view: View{Name: "node_names", Query: "SELECT name FROM node"}},
+		{name: "missing column", view: View{Name: "pod_phases", Query: "SELECT phase FROM pod"}},
+		{name: "not a select", view: View{Name: "pod_deleted", Query: "DELETE FROM pod"}},
+		{name: "table name", view: View{Name: "pod", Query: "SELECT name FROM pod"}},
+		{name: "no name", view: View{Query: "SELECT name FROM pod"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := memory.NewDatabase("kubernetes")
+			db.AddTable("pod", memory.NewTable("pod", sql.NewPrimaryKeySchema(sql.Schema{
+				{Name: "name", Type: sql.Text, Source: "pod"},
+			}), db.GetForeignKeyCollection()))
+			engine := sqle.NewDefault(sql.NewDatabaseProvider(db))
+
+			Create(engine, db, []View{tt.view})
+
+			ctx := sql.NewEmptyContext()
+			_, ok, err := db.GetView(ctx, tt.view.Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != tt.wantCreate {
+				t.Errorf("view %q registered = %v, want %v", tt.view.Name, ok, tt.wantCreate)
+			}
+		})
+	}
+}
+
+// TestCreateOverTables creates the built-in views and a configured one over
+// the schemas of the registered tables.
+func TestCreateOverTables(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "views.yaml")
+	config := `
+views:
+- name: web_pods
+  query: SELECT name, namespace FROM pod WHERE JSON_UNQUOTE(JSON_EXTRACT(labels, '$.app')) = 'web'
+`
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	configured, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db := memory.NewDatabase("kubernetes")
+	for _, provider := range tables.Providers() {
+		db.AddTable(provider.Name, memory.NewTable(provider.Name, sql.NewPrimaryKeySchema(provider.Schema), db.GetForeignKeyCollection()))
+	}
+	engine := sqle.NewDefault(sql.NewDatabaseProvider(db))
+
+	all := append(append([]View{}, Views...), configured...)
+	Create(engine, db, all)
+
+	ctx := sql.NewEmptyContext()
+	for _, view := range all {
+		if _, ok, err := db.GetView(ctx, view.Name); err != nil {
+			t.Fatal(err)
+		} else if !ok {
+			t.Errorf("view %q not created", view.Name)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+// Package clustersql serves the tables registered with package tables over
+// the MySQL protocol. Binaries registering their own tables call Serve after
+// their tables are registered, typically from main:
+//
+//	func init() {
+//		tables.Register(tables.TableProvider{Name: "my_table", ...})
+//	}
+//
+//	func main() {
+//		if err := clustersql.Serve(context.Background(), clustersql.Config{DBName: "kubernetes", Port: 3306}); err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+package clustersql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adalrsjr1/sqlcluster/internal/config"
+	"github.com/adalrsjr1/sqlcluster/internal/functions"
+	"github.com/adalrsjr1/sqlcluster/internal/readonly"
+	"github.com/adalrsjr1/sqlcluster/internal/scratch"
+	"github.com/adalrsjr1/sqlcluster/internal/services"
+	"github.com/adalrsjr1/sqlcluster/internal/views"
+	tb "github.com/adalrsjr1/sqlcluster/pkg/tables"
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/information_schema"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.New().WithField("pkg", "clustersql")
+
+// Config configures the server started by Serve.
+type Config struct {
+	// DBName is the name of the database holding the tables.
+	DBName string
+	// ScratchDBName is the name of the per-session database accepting
+	// writes, none if empty.
+	ScratchDBName string
+	// Address and Port are the address and port the server listens on.
+	Address string
+	Port    int
+	// Tables lists the tables to serve, all registered tables if empty.
+	Tables []string
+	// DisabledTables lists the tables not to serve.
+	DisabledTables []string
+	// TableConfig is a file listing tables to serve or not, in addition to
+	// Tables and DisabledTables.
+	TableConfig string
+	// Retention overrides the Retention of the tables it names.
+	Retention map[string]time.Duration
+	// CRDConfig is a file configuring tables over custom resources.
+	CRDConfig string
+	// ViewConfig is a file configuring views created in addition to the
+	// built-in ones.
+	ViewConfig string
+}
+
+// Serve connects to the cluster, starts feeding the selected tables and
+// serves them until ctx is done.
+func Serve(ctx context.Context, cfg Config) error {
+	if err := services.StartKubernetes(); err != nil {
+		return fmt.Errorf("error to start kubernetes clients: %w", err)
+	}
+
+	db := memory.NewDatabase(cfg.DBName)
+	databases := []sql.Database{db, information_schema.NewInformationSchemaDatabase()}
+	if cfg.ScratchDBName != "" {
+		databases = append(databases, scratch.NewDatabase(cfg.ScratchDBName))
+	}
+	dbProvider := sql.NewDatabaseProvider(databases...)
+	engine := sqle.New(readonly.NewAnalyzer(dbProvider, cfg.DBName), nil)
+	engine.Analyzer.Catalog.RegisterFunction(sql.NewEmptyContext(), functions.Functions...)
+
+	if err := runInformers(ctx, db, cfg); err != nil {
+		return err
+	}
+	if err := createViews(engine, db, cfg.ViewConfig); err != nil {
+		return err
+	}
+
+	serverConfig := server.Config{
+		Protocol: "tcp",
+		Address:  fmt.Sprintf("%s:%d", cfg.Address, cfg.Port),
+	}
+
+	s, err := server.NewServer(serverConfig, engine, scratch.NewSession, nil)
+	if err != nil {
+		return fmt.Errorf("error creating server: %w", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		if err := s.Close(); err != nil {
+			log.WithError(err).Error("error stopping server")
+		} else {
+			log.Info("server stopped")
+		}
+	}()
+
+	if err := s.Start(); err != nil {
+		return fmt.Errorf("error starting server: %w", err)
+	}
+	return nil
+}
+
+func runInformers(ctx context.Context, db *memory.Database, cfg Config) error {
+	if err := registerCRDTables(cfg.CRDConfig); err != nil {
+		return err
+	}
+
+	enabled := tableSet(cfg.Tables)
+	disabled := tableSet(cfg.DisabledTables)
+	if cfg.TableConfig != "" {
+		selection := tableSelection{}
+		if err := config.LoadYAML(cfg.TableConfig, &selection); err != nil {
+			return fmt.Errorf("error loading table selection: %w", err)
+		}
+		for name := range tableSet(selection.Tables) {
+			enabled[name] = struct{}{}
+		}
+		for name := range tableSet(selection.Disable) {
+			disabled[name] = struct{}{}
+		}
+	}
+	allEnabled := len(enabled) == 0
+
+	providers := []tb.TableProvider{}
+	for _, provider := range tb.Providers() {
+		_, isEnabled := enabled[provider.Name]
+		_, isDisabled := disabled[provider.Name]
+		delete(enabled, provider.Name)
+		delete(disabled, provider.Name)
+
+		if (!allEnabled && !isEnabled) || isDisabled {
+			log.Infof("table disabled: %s", provider.Name)
+			continue
+		}
+		if r, ok := cfg.Retention[provider.Name]; ok {
+			provider.Retention = r
+		}
+		providers = append(providers, provider)
+	}
+
+	for name := range enabled {
+		log.Warnf("cannot enable unknown table: %s", name)
+	}
+	for name := range disabled {
+		log.Warnf("cannot disable unknown table: %s", name)
+	}
+
+	tb.Run(ctx, db, providers)
+	return nil
+}
+
+// registerCRDTables registers the tables configured in the CRDConfig file,
+// so they are selected and started like the built-in tables.
+func registerCRDTables(path string) error {
+	if path == "" {
+		return nil
+	}
+	crdTables, err := tb.LoadCRDTables(path)
+	if err != nil {
+		return fmt.Errorf("error loading custom resource tables: %w", err)
+	}
+	for _, table := range crdTables {
+		if err := tb.RegisterCRDTable(table); err != nil {
+			log.WithError(err).Error("cannot register custom resource table")
+		}
+	}
+	return nil
+}
+
+// createViews creates the built-in views and the ones configured in the
+// ViewConfig file, once the tables they select from exist.
+func createViews(engine *sqle.Engine, db *memory.Database, path string) error {
+	all := views.Views
+	if path != "" {
+		configured, err := views.Load(path)
+		if err != nil {
+			return fmt.Errorf("error loading views: %w", err)
+		}
+		all = append(all, configured...)
+	}
+	views.Create(engine, db, all)
+	return nil
+}
+
+// tableSelection lists the tables to serve, all registered tables if empty,
+// and the tables not to serve, like Tables and DisabledTables.
+type tableSelection struct {
+	Tables  []string `json:"tables"`
+	Disable []string `json:"disable"`
+}
+
+func tableSet(names []string) map[string]struct{} {
+	set := map[string]struct{}{}
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			set[name] = struct{}{}
+		}
+	}
+	return set
+}
@@ -0,0 +1,42 @@
+package clustersql_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/adalrsjr1/sqlcluster/pkg/clustersql"
+	"github.com/adalrsjr1/sqlcluster/pkg/tables"
+	"github.com/dolthub/go-mysql-server/sql"
+	v1 "k8s.io/api/core/v1"
+)
+
+// Example serves a table of its own along with the built-in ones.
+func Example() {
+	tables.Register(tables.TableProvider{
+		Name:     "pod_priority",
+		Resource: tables.PodResource,
+		Schema: sql.Schema{
+			{Name: "pod_uid", Type: sql.Text, Nullable: false, PrimaryKey: true},
+			{Name: "priority_class", Type: sql.Text, Nullable: false},
+		},
+		Rows: func(resource interface{}) ([]sql.Row, error) {
+			pod, ok := resource.(*v1.Pod)
+			if !ok {
+				return nil, fmt.Errorf("unexpected type for resource, expected *v1.Pod but got %T", resource)
+			}
+			return []sql.Row{sql.NewRow(string(pod.UID), pod.Spec.PriorityClassName)}, nil
+		},
+	})
+
+	err := clustersql.Serve(context.Background(), clustersql.Config{
+		DBName:         "kubernetes",
+		ScratchDBName:  "scratch",
+		Address:        "0.0.0.0",
+		Port:           3306,
+		DisabledTables: []string{tables.TrafficTableName},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
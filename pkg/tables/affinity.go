@@ -0,0 +1,173 @@
+package tables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	requiredTerm  = "required"
+	preferredTerm = "preferred"
+
+	affinityPolarity     = "affinity"
+	antiAffinityPolarity = "anti-affinity"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     AffinityTableName,
+		Resource: PodResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: AffinityTableName},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: AffinityTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: AffinityTableName},
+			{Name: "kind", Type: sql.Text, Nullable: false, Source: AffinityTableName},
+			{Name: "polarity", Type: sql.Text, Nullable: false, Source: AffinityTableName},
+			{Name: "weight", Type: sql.Int32, Nullable: true, Source: AffinityTableName},
+			{Name: "topology_key", Type: sql.Text, Nullable: false, Source: AffinityTableName},
+			{Name: "affinity", Type: sql.Text, Nullable: false, Source: AffinityTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: AffinityTableName},
+		},
+		Rows:    affinityRows,
+		Lookups: []Resource{NamespaceResource},
+	})
+}
+
+// podAffinityTerm is a pod (anti-)affinity term flattened with the kind,
+// polarity and weight it was declared with. Required terms have no weight.
+type podAffinityTerm struct {
+	v1.PodAffinityTerm
+	kind     string
+	polarity string
+	weight   *int32
+}
+
+func affinityRows(resource interface{}) ([]sql.Row, error) {
+	pod, ok := resource.(*v1.Pod)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *v1.Pod but got %T", resource)
+	}
+
+	rows := []sql.Row{}
+	for _, term := range podAffinityTerms(pod) {
+		selectedPods, err := lookupPods(pod, &term.PodAffinityTerm)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, affinityPod := range selectedPods {
+			rows = append(rows, affinityRow(pod, affinityPod, &term))
+		}
+	}
+
+	return rows, nil
+}
+
+// podAffinityTerms lists the required and preferred terms of both the pod
+// affinity and the pod anti-affinity of a pod.
+func podAffinityTerms(pod *v1.Pod) []podAffinityTerm {
+	terms := []podAffinityTerm{}
+	if pod.Spec.Affinity == nil {
+		return terms
+	}
+
+	add := func(polarity string, required []v1.PodAffinityTerm, preferred []v1.WeightedPodAffinityTerm) {
+		for _, term := range required {
+			terms = append(terms, podAffinityTerm{PodAffinityTerm: term, kind: requiredTerm, polarity: polarity})
+		}
+		for i := range preferred {
+			terms = append(terms, podAffinityTerm{
+				PodAffinityTerm: preferred[i].PodAffinityTerm,
+				kind:            preferredTerm,
+				polarity:        polarity,
+				weight:          &preferred[i].Weight,
+			})
+		}
+	}
+
+	if affinity := pod.Spec.Affinity.PodAffinity; affinity != nil {
+		add(affinityPolarity,
+			affinity.RequiredDuringSchedulingIgnoredDuringExecution,
+			affinity.PreferredDuringSchedulingIgnoredDuringExecution)
+	}
+	if antiAffinity := pod.Spec.Affinity.PodAntiAffinity; antiAffinity != nil {
+		add(antiAffinityPolarity,
+			antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+			antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+	}
+
+	return terms
+}
+
+// lookupPods matches a pod affinity term against the pod cache. The term
+// applies to the namespaces it lists or selects, or to the namespace of the
+// pod declaring it when it does neither.
+func lookupPods(pod *v1.Pod, term *v1.PodAffinityTerm) ([]*v1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+	inNamespace, err := affinityNamespaces(pod, term)
+	if err != nil {
+		return nil, err
+	}
+
+	store, ok := LookupCache(PodResource)
+	if !ok {
+		return nil, nil
+	}
+
+	selected := []*v1.Pod{}
+	for _, obj := range store.List() {
+		candidate, ok := obj.(*v1.Pod)
+		if !ok || !inNamespace(candidate.Namespace) {
+			continue
+		}
+		if selector.Matches(labels.Set(candidate.Labels)) {
+			selected = append(selected, candidate)
+		}
+	}
+	return selected, nil
+}
+
+func affinityNamespaces(pod *v1.Pod, term *v1.PodAffinityTerm) (func(namespace string) bool, error) {
+	namespaces := sets.NewString(term.Namespaces...)
+	if term.NamespaceSelector == nil {
+		if namespaces.Len() == 0 {
+			namespaces.Insert(pod.Namespace)
+		}
+		return namespaces.Has, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(term.NamespaceSelector)
+	if err != nil {
+		return nil, err
+	}
+	if selector.Empty() {
+		return func(string) bool { return true }, nil
+	}
+
+	if store, ok := LookupCache(NamespaceResource); ok {
+		for _, obj := range store.List() {
+			if namespace, ok := obj.(*v1.Namespace); ok && selector.Matches(labels.Set(namespace.Labels)) {
+				namespaces.Insert(namespace.Name)
+			}
+		}
+	}
+	return namespaces.Has, nil
+}
+
+func affinityRow(pod, affinityPod *v1.Pod, term *podAffinityTerm) sql.Row {
+	return sql.NewRow(string(pod.UID), pod.Name, pod.Namespace,
+		term.kind,
+		term.polarity,
+		nullableInt32(term.weight),
+		term.TopologyKey,
+		affinityPod.Name,
+		pod.CreationTimestamp.Time)
+}
@@ -0,0 +1,53 @@
+package tables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	v1 "k8s.io/api/core/v1"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     ContainerTableName,
+		Resource: PodResource,
+		Schema: sql.Schema{
+			{Name: "pod_uid", Type: sql.Text, Nullable: false, Source: ContainerTableName},
+			{Name: "pod", Type: sql.Text, Nullable: false, Source: ContainerTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: ContainerTableName},
+			{Name: "container", Type: sql.Text, Nullable: false, Source: ContainerTableName},
+			{Name: "limit_memory", Type: sql.Int64, Nullable: false, Source: ContainerTableName},
+			{Name: "limit_cpu", Type: sql.Int64, Nullable: false, Source: ContainerTableName},
+			{Name: "limit_disk", Type: sql.Int64, Nullable: false, Source: ContainerTableName},
+			{Name: "request_memory", Type: sql.Int64, Nullable: false, Source: ContainerTableName},
+			{Name: "request_cpu", Type: sql.Int64, Nullable: false, Source: ContainerTableName},
+			{Name: "request_disk", Type: sql.Int64, Nullable: false, Source: ContainerTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: ContainerTableName},
+		},
+		Rows: containerRows,
+	})
+}
+
+func containerRows(resource interface{}) ([]sql.Row, error) {
+	pod, ok := resource.(*v1.Pod)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *v1.Pod but got %T", resource)
+	}
+
+	rows := make([]sql.Row, 0, len(pod.Spec.Containers))
+	for _, container := range pod.Spec.Containers {
+		rows = append(rows, containerRow(pod, &container))
+	}
+	return rows, nil
+}
+
+func containerRow(pod *v1.Pod, container *v1.Container) sql.Row {
+	return sql.NewRow(string(pod.UID), pod.Name, pod.Namespace, container.Name,
+		container.Resources.Limits.Memory().Value(),
+		container.Resources.Limits.Cpu().MilliValue(),
+		container.Resources.Limits.StorageEphemeral().Value(),
+		container.Resources.Requests.Memory().Value(),
+		container.Resources.Requests.Cpu().MilliValue(),
+		container.Resources.Requests.StorageEphemeral().Value(),
+		pod.CreationTimestamp.Time)
+}
@@ -0,0 +1,282 @@
+package tables
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	regularContainer   = "regular"
+	initContainer      = "init"
+	ephemeralContainer = "ephemeral"
+)
+
+// DefaultTerminationRetention is how long container terminations are kept
+// after they finished, unless the container_termination table sets another
+// Retention.
+const DefaultTerminationRetention = 24 * time.Hour
+
+func init() {
+	Register(TableProvider{
+		Name:     ContainerStatusTableName,
+		Resource: PodResource,
+		Schema: sql.Schema{
+			{Name: "pod_uid", Type: sql.Text, Nullable: false, Source: ContainerStatusTableName},
+			{Name: "pod", Type: sql.Text, Nullable: false, Source: ContainerStatusTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: ContainerStatusTableName},
+			{Name: "container", Type: sql.Text, Nullable: false, Source: ContainerStatusTableName},
+			{Name: "container_type", Type: sql.Text, Nullable: false, Source: ContainerStatusTableName},
+			{Name: "container_id", Type: sql.Text, Nullable: false, Source: ContainerStatusTableName},
+			{Name: "image", Type: sql.Text, Nullable: false, Source: ContainerStatusTableName},
+			{Name: "image_id", Type: sql.Text, Nullable: false, Source: ContainerStatusTableName},
+			{Name: "ready", Type: sql.Boolean, Nullable: false, Source: ContainerStatusTableName},
+			{Name: "started", Type: sql.Boolean, Nullable: true, Source: ContainerStatusTableName},
+			{Name: "restart_count", Type: sql.Int32, Nullable: false, Source: ContainerStatusTableName},
+			{Name: "state", Type: sql.Text, Nullable: false, Source: ContainerStatusTableName},
+			{Name: "state_reason", Type: sql.Text, Nullable: false, Source: ContainerStatusTableName},
+			{Name: "last_terminated_reason", Type: sql.Text, Nullable: false, Source: ContainerStatusTableName},
+			{Name: "last_terminated_exit_code", Type: sql.Int32, Nullable: true, Source: ContainerStatusTableName},
+			{Name: "last_terminated_finished_at", Type: sql.Datetime, Nullable: true, Source: ContainerStatusTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: ContainerStatusTableName},
+		},
+		Rows: containerStatusRows,
+	})
+
+	Register(TableProvider{
+		Name:     ContainerTerminationTableName,
+		Resource: PodResource,
+		Schema: sql.Schema{
+			{Name: "pod_uid", Type: sql.Text, Nullable: false, Source: ContainerTerminationTableName},
+			{Name: "pod", Type: sql.Text, Nullable: false, Source: ContainerTerminationTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: ContainerTerminationTableName},
+			{Name: "container", Type: sql.Text, Nullable: false, Source: ContainerTerminationTableName},
+			{Name: "container_type", Type: sql.Text, Nullable: false, Source: ContainerTerminationTableName},
+			{Name: "container_id", Type: sql.Text, Nullable: false, Source: ContainerTerminationTableName},
+			{Name: "restart_count", Type: sql.Int32, Nullable: false, Source: ContainerTerminationTableName},
+			{Name: "reason", Type: sql.Text, Nullable: false, Source: ContainerTerminationTableName},
+			{Name: "message", Type: sql.Text, Nullable: false, Source: ContainerTerminationTableName},
+			{Name: "exit_code", Type: sql.Int32, Nullable: false, Source: ContainerTerminationTableName},
+			{Name: "term_signal", Type: sql.Int32, Nullable: false, Source: ContainerTerminationTableName},
+			{Name: "started_at", Type: sql.Datetime, Nullable: true, Source: ContainerTerminationTableName},
+			{Name: "finished_at", Type: sql.Datetime, Nullable: true, Source: ContainerTerminationTableName},
+		},
+		Rows:      containerTerminationRows,
+		Retention: DefaultTerminationRetention,
+		Objects: func(retention time.Duration) ObjectSource {
+			return newTerminationHistory(retention)
+		},
+	})
+}
+
+// typedContainerStatus is a container status along with the kind of container
+// it belongs to.
+type typedContainerStatus struct {
+	containerType string
+	status        *v1.ContainerStatus
+}
+
+func containerStatuses(pod *v1.Pod) []typedContainerStatus {
+	statuses := make([]typedContainerStatus, 0,
+		len(pod.Status.InitContainerStatuses)+len(pod.Status.ContainerStatuses)+len(pod.Status.EphemeralContainerStatuses))
+	for i := range pod.Status.InitContainerStatuses {
+		statuses = append(statuses, typedContainerStatus{initContainer, &pod.Status.InitContainerStatuses[i]})
+	}
+	for i := range pod.Status.ContainerStatuses {
+		statuses = append(statuses, typedContainerStatus{regularContainer, &pod.Status.ContainerStatuses[i]})
+	}
+	for i := range pod.Status.EphemeralContainerStatuses {
+		statuses = append(statuses, typedContainerStatus{ephemeralContainer, &pod.Status.EphemeralContainerStatuses[i]})
+	}
+	return statuses
+}
+
+func containerStatusRows(resource interface{}) ([]sql.Row, error) {
+	pod, ok := resource.(*v1.Pod)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *v1.Pod but got %T", resource)
+	}
+
+	statuses := containerStatuses(pod)
+	rows := make([]sql.Row, 0, len(statuses))
+	for _, status := range statuses {
+		rows = append(rows, containerStatusRow(pod, status.containerType, status.status))
+	}
+	return rows, nil
+}
+
+func containerStatusRow(pod *v1.Pod, containerType string, status *v1.ContainerStatus) sql.Row {
+	state, reason := containerState(&status.State)
+
+	lastReason := ""
+	var lastExitCode, lastFinishedAt interface{}
+	if last := status.LastTerminationState.Terminated; last != nil {
+		lastReason = last.Reason
+		lastExitCode = last.ExitCode
+		lastFinishedAt = nullableTime(last.FinishedAt.Time)
+	}
+
+	return sql.NewRow(string(pod.UID), pod.Name, pod.Namespace, status.Name, containerType,
+		status.ContainerID,
+		status.Image,
+		status.ImageID,
+		boolValue(status.Ready),
+		nullableBool(status.Started),
+		status.RestartCount,
+		state, reason,
+		lastReason, lastExitCode, lastFinishedAt,
+		pod.CreationTimestamp.Time)
+}
+
+// containerState returns the current state of a container and its reason.
+func containerState(state *v1.ContainerState) (string, string) {
+	switch {
+	case state.Running != nil:
+		return "running", ""
+	case state.Terminated != nil:
+		return "terminated", state.Terminated.Reason
+	case state.Waiting != nil:
+		return "waiting", state.Waiting.Reason
+	default:
+		return "", ""
+	}
+}
+
+// containerTermination is a container termination observed on a pod.
+type containerTermination struct {
+	podUID        string
+	pod           string
+	namespace     string
+	container     string
+	containerType string
+	restartCount  int32
+	state         v1.ContainerStateTerminated
+	recordedAt    time.Time
+}
+
+func containerTerminationRows(resource interface{}) ([]sql.Row, error) {
+	termination, ok := resource.(*containerTermination)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *containerTermination but got %T", resource)
+	}
+
+	return []sql.Row{sql.NewRow(termination.podUID, termination.pod, termination.namespace,
+		termination.container, termination.containerType,
+		termination.state.ContainerID,
+		termination.restartCount,
+		termination.state.Reason,
+		termination.state.Message,
+		termination.state.ExitCode,
+		termination.state.Signal,
+		nullableTime(termination.state.StartedAt.Time),
+		nullableTime(termination.state.FinishedAt.Time))}, nil
+}
+
+// terminationHistory records the container terminations reported by pod
+// events, including those the pod status no longer shows after further
+// restarts or once the pod is deleted. Terminations are kept until retention
+// has elapsed since they finished, or since they started or were recorded
+// when the kubelet does not report when they finished.
+type terminationHistory struct {
+	mu           sync.Mutex
+	retention    time.Duration
+	terminations map[string]*containerTermination
+}
+
+var _ ObjectSource = (*terminationHistory)(nil)
+
+func newTerminationHistory(retention time.Duration) *terminationHistory {
+	return &terminationHistory{
+		retention:    retention,
+		terminations: map[string]*containerTermination{},
+	}
+}
+
+func (h *terminationHistory) OnAdd(obj interface{}) {
+	h.record(obj)
+}
+
+func (h *terminationHistory) OnUpdate(_, newObj interface{}) {
+	h.record(newObj)
+}
+
+func (h *terminationHistory) OnDelete(obj interface{}) {
+	if deleted, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = deleted.Obj
+	}
+	h.record(obj)
+}
+
+func (h *terminationHistory) record(obj interface{}) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok {
+		log.Warnf("cannot record terminations of object of type %T", obj)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := time.Now()
+	h.prune(now)
+
+	for _, status := range containerStatuses(pod) {
+		for _, terminated := range []*v1.ContainerStateTerminated{status.status.LastTerminationState.Terminated, status.status.State.Terminated} {
+			if terminated == nil {
+				continue
+			}
+
+			key := fmt.Sprintf("%s/%s/%s/%d", pod.UID, status.status.Name, terminated.ContainerID, terminated.FinishedAt.Unix())
+			if _, ok := h.terminations[key]; ok {
+				continue
+			}
+			termination := &containerTermination{
+				podUID:        string(pod.UID),
+				pod:           pod.Name,
+				namespace:     pod.Namespace,
+				container:     status.status.Name,
+				containerType: status.containerType,
+				restartCount:  status.status.RestartCount,
+				state:         *terminated,
+				recordedAt:    now,
+			}
+			if !h.expired(termination, now) {
+				h.terminations[key] = termination
+			}
+		}
+	}
+}
+
+func (h *terminationHistory) List() []interface{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.prune(time.Now())
+
+	terminations := make([]interface{}, 0, len(h.terminations))
+	for _, termination := range h.terminations {
+		terminations = append(terminations, termination)
+	}
+	return terminations
+}
+
+func (h *terminationHistory) expired(termination *containerTermination, now time.Time) bool {
+	since := termination.recordedAt
+	switch {
+	case !termination.state.FinishedAt.IsZero():
+		since = termination.state.FinishedAt.Time
+	case !termination.state.StartedAt.IsZero():
+		since = termination.state.StartedAt.Time
+	}
+	return now.Sub(since) > h.retention
+}
+
+func (h *terminationHistory) prune(now time.Time) {
+	for key, termination := range h.terminations {
+		if h.expired(termination, now) {
+			delete(h.terminations, key)
+		}
+	}
+}
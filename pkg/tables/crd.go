@@ -0,0 +1,251 @@
+package tables
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/adalrsjr1/sqlcluster/internal/config"
+	"github.com/dolthub/go-mysql-server/sql"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// CRDTable configures a table over a custom resource, or any resource
+// without a typed table, read through the dynamic client.
+type CRDTable struct {
+	// Name is the SQL name of the table. It defaults to Resource.
+	Name string `json:"name"`
+	// Group, Version and Resource identify the watched resource. An empty
+	// Version selects the version preferred by the API server.
+	Group    string `json:"group"`
+	Version  string `json:"version"`
+	Resource string `json:"resource"`
+	// Columns maps JSONPath expressions to typed columns, appended after
+	// the standard columns.
+	Columns []CRDColumn `json:"columns"`
+}
+
+// CRDColumn maps the first value matched by a JSONPath expression, such as
+// {.spec.replicas}, to a column. Type is one of text, int, float, bool,
+// datetime or json and defaults to text. Unmatched paths are NULL.
+type CRDColumn struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	Type string `json:"type"`
+}
+
+type crdConfig struct {
+	Tables []CRDTable `json:"tables"`
+}
+
+var crdColumnTypes = map[string]sql.Type{
+	"":         sql.Text,
+	"text":     sql.Text,
+	"int":      sql.Int64,
+	"float":    sql.Float64,
+	"bool":     sql.Boolean,
+	"datetime": sql.Datetime,
+	"json":     sql.JSON,
+}
+
+// LoadCRDTables reads the tables configured in a YAML or JSON file.
+func LoadCRDTables(path string) ([]CRDTable, error) {
+	crds := crdConfig{}
+	if err := config.LoadYAML(path, &crds); err != nil {
+		return nil, err
+	}
+	return crds.Tables, nil
+}
+
+// RegisterCRDTable makes a configured table available to the server. Unlike
+// Register, it returns an error rather than panicking on invalid tables, as
+// they come from user configuration.
+func RegisterCRDTable(table CRDTable) error {
+	if table.Resource == "" {
+		return fmt.Errorf("table %q: resource is required", table.Name)
+	}
+	if table.Name == "" {
+		table.Name = table.Resource
+	}
+
+	gvr := schema.GroupVersionResource{Group: table.Group, Version: table.Version, Resource: table.Resource}
+	resource := Resource(gvr.GroupResource().String())
+
+	provider, err := crdProvider(table, resource)
+	if err != nil {
+		return err
+	}
+
+	registryMu.RLock()
+	_, taken := providers[provider.Name]
+	watch, err := crdWatch(resource)
+	registryMu.RUnlock()
+
+	if taken {
+		return fmt.Errorf("table %q: name already taken", table.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("table %q: %w", table.Name, err)
+	}
+	if watch {
+		RegisterDynamicResource(resource, gvr)
+	}
+	Register(provider)
+	return nil
+}
+
+// crdWatch tells whether the resource of a configured table must be
+// registered as a dynamic resource, or is already watched through the
+// dynamic client. Resources with a typed informer are rejected, as their
+// objects are not unstructured. It must be called with registryMu held.
+func crdWatch(resource Resource) (bool, error) {
+	if _, ok := resources[resource]; ok {
+		return false, fmt.Errorf("resource %s has typed tables and cannot be configured", resource)
+	}
+	_, ok := dynamicResources[resource]
+	return !ok, nil
+}
+
+// crdColumnPath is the JSONPath of a configured column. JSONPath keeps the
+// state of the ongoing search, so concurrent scans take turns.
+type crdColumnPath struct {
+	mu   sync.Mutex
+	path *jsonpath.JSONPath
+}
+
+func crdProvider(table CRDTable, resource Resource) (TableProvider, error) {
+	columns := sql.Schema{
+		{Name: "uid", Type: sql.Text, Nullable: false, Source: table.Name, PrimaryKey: true},
+		{Name: "name", Type: sql.Text, Nullable: false, Source: table.Name},
+		{Name: "namespace", Type: sql.Text, Nullable: false, Source: table.Name},
+		{Name: "api_version", Type: sql.Text, Nullable: false, Source: table.Name},
+		{Name: "kind", Type: sql.Text, Nullable: false, Source: table.Name},
+		{Name: "labels", Type: sql.JSON, Nullable: false, Source: table.Name},
+		{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: table.Name},
+		{Name: "deleted_at", Type: sql.Datetime, Nullable: true, Source: table.Name},
+		{Name: "spec", Type: sql.JSON, Nullable: true, Source: table.Name},
+		{Name: "status", Type: sql.JSON, Nullable: true, Source: table.Name},
+	}
+
+	names := map[string]struct{}{}
+	for _, column := range columns {
+		names[column.Name] = struct{}{}
+	}
+
+	paths := make([]*crdColumnPath, len(table.Columns))
+	for i, column := range table.Columns {
+		columnType, ok := crdColumnTypes[column.Type]
+		if !ok {
+			return TableProvider{}, fmt.Errorf("table %q: column %q has unknown type %q", table.Name, column.Name, column.Type)
+		}
+		if column.Name == "" {
+			return TableProvider{}, fmt.Errorf("table %q: column %d has no name", table.Name, i)
+		}
+		if _, ok := names[strings.ToLower(column.Name)]; ok {
+			return TableProvider{}, fmt.Errorf("table %q: duplicate column %q", table.Name, column.Name)
+		}
+		names[strings.ToLower(column.Name)] = struct{}{}
+		path := jsonpath.New(column.Name).AllowMissingKeys(true)
+		if err := path.Parse(column.Path); err != nil {
+			return TableProvider{}, fmt.Errorf("table %q: column %q: %w", table.Name, column.Name, err)
+		}
+		paths[i] = &crdColumnPath{path: path}
+		columns = append(columns, &sql.Column{Name: column.Name, Type: columnType, Nullable: true, Source: table.Name})
+	}
+
+	return TableProvider{
+		Name:     table.Name,
+		Resource: resource,
+		Schema:   columns,
+		Rows: func(obj interface{}) ([]sql.Row, error) {
+			return crdRows(obj, table.Columns, paths)
+		},
+	}, nil
+}
+
+func crdRows(resource interface{}, columns []CRDColumn, paths []*crdColumnPath) ([]sql.Row, error) {
+	obj, ok := resource.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *unstructured.Unstructured but got %T", resource)
+	}
+
+	created := obj.GetCreationTimestamp()
+	row := sql.NewRow(string(obj.GetUID()), obj.GetName(), obj.GetNamespace(),
+		obj.GetAPIVersion(),
+		obj.GetKind(),
+		mapJSON(obj.GetLabels()),
+		created.Time,
+		nullableMetaTime(obj.GetDeletionTimestamp()),
+		nestedJSON(obj.Object, "spec"),
+		nestedJSON(obj.Object, "status"))
+
+	for i, column := range columns {
+		paths[i].mu.Lock()
+		value, err := jsonPathValue(paths[i].path, obj.Object, column.Type)
+		paths[i].mu.Unlock()
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", column.Name, err)
+		}
+		row = append(row, value)
+	}
+	return []sql.Row{row}, nil
+}
+
+// nestedJSON reads a field of an unstructured object as a JSON document, or
+// NULL when the field is absent.
+func nestedJSON(obj map[string]interface{}, fields ...string) interface{} {
+	value, found, err := unstructured.NestedFieldNoCopy(obj, fields...)
+	if err != nil || !found {
+		return nil
+	}
+	return sql.JSONDocument{Val: value}
+}
+
+// jsonPathValue converts the first value matched by path to the Go type of
+// the column type, or returns nil when nothing matches.
+func jsonPathValue(path *jsonpath.JSONPath, obj map[string]interface{}, columnType string) (interface{}, error) {
+	results, err := path.FindResults(obj)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 || len(results[0]) == 0 {
+		return nil, nil
+	}
+	value := results[0][0].Interface()
+	if value == nil {
+		return nil, nil
+	}
+
+	switch columnType {
+	case "json":
+		return sql.JSONDocument{Val: value}, nil
+	case "datetime":
+		var t metav1.Time
+		if err := t.UnmarshalQueryParameter(fmt.Sprint(value)); err != nil {
+			return nil, err
+		}
+		return nullableTime(t.Time), nil
+	case "bool":
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected bool but got %T", value)
+		}
+		return boolValue(b), nil
+	case "int", "float":
+		return crdColumnTypes[columnType].Convert(value)
+	default:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+		var buf bytes.Buffer
+		if err := path.PrintResults(&buf, []reflect.Value{results[0][0]}); err != nil {
+			return nil, err
+		}
+		return buf.String(), nil
+	}
+}
@@ -0,0 +1,210 @@
+package tables
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+func TestRegisterCRDTableErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		table   CRDTable
+		wantErr string
+	}{
+		{
+			name:    "missing resource",
+			table:   CRDTable{Name: "rollout", Group: "argoproj.io"},
+			wantErr: "resource is required",
+		},
+		{
+			name:    "typed resource",
+			table:   CRDTable{Name: "my_deployment", Group: "apps", Resource: "deployments"},
+			wantErr: "has typed tables",
+		},
+		{
+			name:    "taken name",
+			table:   CRDTable{Name: PodTableName, Group: "argoproj.io", Resource: "rollouts"},
+			wantErr: "name already taken",
+		},
+		{
+			name: "duplicate standard column",
+			table: CRDTable{Name: "rollout", Group: "argoproj.io", Resource: "rollouts",
+				Columns: []CRDColumn{{Name: "Name", Path: "{.spec.name}"}}},
+			wantErr: "duplicate column",
+		},
+		{
+			name: "duplicate column",
+			table: CRDTable{Name: "rollout", Group: "argoproj.io", Resource: "rollouts",
+				Columns: []CRDColumn{{Name: "phase", Path: "{.status.phase}"}, {Name: "phase", Path: "{.status.message}"}}},
+			wantErr: "duplicate column",
+		},
+		{
+			name: "unknown type",
+			table: CRDTable{Name: "rollout", Group: "argoproj.io", Resource: "rollouts",
+				Columns: []CRDColumn{{Name: "replicas", Path: "{.spec.replicas}", Type: "integer"}}},
+			wantErr: "unknown type",
+		},
+		{
+			name: "invalid path",
+			table: CRDTable{Name: "rollout", Group: "argoproj.io", Resource: "rollouts",
+				Columns: []CRDColumn{{Name: "replicas", Path: "{.spec.replicas"}}},
+			wantErr: "replicas",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterCRDTable(tt.table)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("RegisterCRDTable() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCRDWatch(t *testing.T) {
+	tests := []struct {
+		resource  Resource
+		wantWatch bool
+		wantErr   bool
+	}{
+		{resource: DeploymentResource, wantErr: true},
+		{resource: PodResource, wantErr: true},
+		{resource: GatewayResource, wantWatch: false},
+		{resource: "rollouts.argoproj.io", wantWatch: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.resource), func(t *testing.T) {
+			registryMu.RLock()
+			watch, err := crdWatch(tt.resource)
+			registryMu.RUnlock()
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("crdWatch() error = %v, want error %v", err, tt.wantErr)
+			}
+			if watch != tt.wantWatch {
+				t.Errorf("crdWatch() = %v, want %v", watch, tt.wantWatch)
+			}
+		})
+	}
+}
+
+func TestJSONPathValue(t *testing.T) {
+	obj := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+			"weight":   0.5,
+			"paused":   true,
+			"scale":    2.0,
+			"name":     "canary",
+			"steps":    []interface{}{map[string]interface{}{"setWeight": int64(20)}},
+		},
+		"status": map[string]interface{}{
+			"updatedAt": "2023-01-02T03:04:05Z",
+			"phase":     nil,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		columnType string
+		want       interface{}
+		wantErr    bool
+	}{
+		{name: "int", path: "{.spec.replicas}", columnType: "int", want: int64(3)},
+		{name: "int from float", path: "{.spec.scale}", columnType: "int", want: int64(2)},
+		{name: "float", path: "{.spec.weight}", columnType: "float", want: 0.5},
+		{name: "float from int", path: "{.spec.replicas}", columnType: "float", want: float64(3)},
+		{name: "bool", path: "{.spec.paused}", columnType: "bool", want: int8(1)},
+		{name: "bool from string", path: "{.spec.name}", columnType: "bool", wantErr: true},
+		{name: "datetime", path: "{.status.updatedAt}", columnType: "datetime", want: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{name: "invalid datetime", path: "{.spec.name}", columnType: "datetime", wantErr: true},
+		{name: "json", path: "{.spec.steps}", columnType: "json", want: sql.JSONDocument{Val: obj["spec"].(map[string]interface{})["steps"]}},
+		{name: "text", path: "{.spec.name}", columnType: "text", want: "canary"},
+		{name: "text from int", path: "{.spec.replicas}", columnType: "", want: "3"},
+		{name: "text from object", path: "{.spec.steps[0]}", columnType: "text", want: `{"setWeight":20}`},
+		{name: "missing", path: "{.spec.missing}", columnType: "int", want: nil},
+		{name: "null", path: "{.status.phase}", columnType: "text", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := jsonpath.New(tt.name).AllowMissingKeys(true)
+			if err := path.Parse(tt.path); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := jsonPathValue(path, obj, tt.columnType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("jsonPathValue() error = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if gotTime, ok := got.(time.Time); ok {
+				if !gotTime.Equal(tt.want.(time.Time)) {
+					t.Errorf("jsonPathValue() = %v, want %v", got, tt.want)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("jsonPathValue() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCRDRowsConcurrent scans a table from several goroutines, as concurrent
+// sessions do. Run with -race.
+func TestCRDRowsConcurrent(t *testing.T) {
+	provider, err := crdProvider(CRDTable{
+		Name:     "rollout",
+		Group:    "argoproj.io",
+		Resource: "rollouts",
+		Columns: []CRDColumn{
+			{Name: "replicas", Path: "{.spec.replicas}", Type: "int"},
+			{Name: "phase", Path: "{.status.phase}"},
+		},
+	}, "rollouts.argoproj.io")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Rollout",
+		"metadata":   map[string]interface{}{"name": "web", "namespace": "default", "uid": "u1"},
+		"spec":       map[string]interface{}{"replicas": int64(3)},
+		"status":     map[string]interface{}{"phase": "Healthy"},
+	}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				rows, err := provider.Rows(obj)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				row := rows[0]
+				if got := row[len(row)-2:]; got[0] != int64(3) || got[1] != "Healthy" {
+					t.Errorf("Rows() columns = %v, want [3 Healthy]", got)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
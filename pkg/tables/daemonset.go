@@ -0,0 +1,58 @@
+package tables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     DaemonSetTableName,
+		Resource: DaemonSetResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: DaemonSetTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: DaemonSetTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: DaemonSetTableName},
+			{Name: "desired_replicas", Type: sql.Int32, Nullable: false, Source: DaemonSetTableName},
+			{Name: "replicas", Type: sql.Int32, Nullable: false, Source: DaemonSetTableName},
+			{Name: "ready_replicas", Type: sql.Int32, Nullable: false, Source: DaemonSetTableName},
+			{Name: "available_replicas", Type: sql.Int32, Nullable: false, Source: DaemonSetTableName},
+			{Name: "updated_replicas", Type: sql.Int32, Nullable: false, Source: DaemonSetTableName},
+			{Name: "strategy", Type: sql.Text, Nullable: false, Source: DaemonSetTableName},
+			{Name: "selector", Type: sql.Text, Nullable: false, Source: DaemonSetTableName},
+			{Name: "generation", Type: sql.Int64, Nullable: false, Source: DaemonSetTableName},
+			{Name: "observed_generation", Type: sql.Int64, Nullable: false, Source: DaemonSetTableName},
+			{Name: "labels", Type: sql.JSON, Nullable: false, Source: DaemonSetTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: DaemonSetTableName},
+		},
+		Rows: daemonSetRows,
+	})
+}
+
+func daemonSetRows(resource interface{}) ([]sql.Row, error) {
+	daemonSet, ok := resource.(*appsv1.DaemonSet)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *appsv1.DaemonSet but got %T", resource)
+	}
+	return []sql.Row{daemonSetRow(daemonSet)}, nil
+}
+
+// daemonSetRow maps the scheduled pod counters of a DaemonSet onto replicas,
+// the desired count being the number of nodes that should run the daemon.
+func daemonSetRow(daemonSet *appsv1.DaemonSet) sql.Row {
+	return sql.NewRow(string(daemonSet.UID), daemonSet.Name, daemonSet.Namespace,
+		daemonSet.Status.DesiredNumberScheduled,
+		daemonSet.Status.CurrentNumberScheduled,
+		daemonSet.Status.NumberReady,
+		daemonSet.Status.NumberAvailable,
+		daemonSet.Status.UpdatedNumberScheduled,
+		string(daemonSet.Spec.UpdateStrategy.Type),
+		metav1.FormatLabelSelector(daemonSet.Spec.Selector),
+		daemonSet.Generation,
+		daemonSet.Status.ObservedGeneration,
+		mapJSON(daemonSet.Labels),
+		daemonSet.CreationTimestamp.Time)
+}
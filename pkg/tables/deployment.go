@@ -0,0 +1,64 @@
+package tables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     DeploymentTableName,
+		Resource: DeploymentResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: DeploymentTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: DeploymentTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: DeploymentTableName},
+			{Name: "desired_replicas", Type: sql.Int32, Nullable: false, Source: DeploymentTableName},
+			{Name: "replicas", Type: sql.Int32, Nullable: false, Source: DeploymentTableName},
+			{Name: "ready_replicas", Type: sql.Int32, Nullable: false, Source: DeploymentTableName},
+			{Name: "available_replicas", Type: sql.Int32, Nullable: false, Source: DeploymentTableName},
+			{Name: "updated_replicas", Type: sql.Int32, Nullable: false, Source: DeploymentTableName},
+			{Name: "strategy", Type: sql.Text, Nullable: false, Source: DeploymentTableName},
+			{Name: "selector", Type: sql.Text, Nullable: false, Source: DeploymentTableName},
+			{Name: "generation", Type: sql.Int64, Nullable: false, Source: DeploymentTableName},
+			{Name: "observed_generation", Type: sql.Int64, Nullable: false, Source: DeploymentTableName},
+			{Name: "labels", Type: sql.JSON, Nullable: false, Source: DeploymentTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: DeploymentTableName},
+		},
+		Rows: deploymentRows,
+	})
+}
+
+func deploymentRows(resource interface{}) ([]sql.Row, error) {
+	deployment, ok := resource.(*appsv1.Deployment)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *appsv1.Deployment but got %T", resource)
+	}
+	return []sql.Row{deploymentRow(deployment)}, nil
+}
+
+func deploymentRow(deployment *appsv1.Deployment) sql.Row {
+	return sql.NewRow(string(deployment.UID), deployment.Name, deployment.Namespace,
+		desiredReplicas(deployment.Spec.Replicas),
+		deployment.Status.Replicas,
+		deployment.Status.ReadyReplicas,
+		deployment.Status.AvailableReplicas,
+		deployment.Status.UpdatedReplicas,
+		string(deployment.Spec.Strategy.Type),
+		metav1.FormatLabelSelector(deployment.Spec.Selector),
+		deployment.Generation,
+		deployment.Status.ObservedGeneration,
+		mapJSON(deployment.Labels),
+		deployment.CreationTimestamp.Time)
+}
+
+// desiredReplicas resolves an unset replicas field to the API default of 1.
+func desiredReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
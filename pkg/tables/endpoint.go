@@ -0,0 +1,47 @@
+package tables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	v1 "k8s.io/api/core/v1"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     EndpointTableName,
+		Resource: EndpointsResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: EndpointTableName},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: EndpointTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: EndpointTableName},
+			{Name: "hostname", Type: sql.Text, Nullable: false, Source: EndpointTableName},
+			{Name: "ip", Type: sql.Text, Nullable: false, Source: EndpointTableName},
+			{Name: "portname", Type: sql.Text, Nullable: false, Source: EndpointTableName},
+			{Name: "port", Type: sql.Int32, Nullable: false, Source: EndpointTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: EndpointTableName},
+		},
+		Rows: endpointRows,
+	})
+}
+
+func endpointRows(resource interface{}) ([]sql.Row, error) {
+	svc, ok := resource.(*v1.Endpoints)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *v1.Endpoints but got %T", resource)
+	}
+
+	rows := []sql.Row{}
+	for _, endpoint := range svc.Subsets {
+		for _, addr := range endpoint.Addresses {
+			for _, port := range endpoint.Ports {
+				rows = append(rows, svcRow(svc, &addr, &port))
+			}
+		}
+	}
+	return rows, nil
+}
+
+func svcRow(svc *v1.Endpoints, addr *v1.EndpointAddress, port *v1.EndpointPort) sql.Row {
+	return sql.NewRow(string(svc.UID), svc.Name, svc.Namespace, addr.Hostname, addr.IP, port.Name, port.Port, svc.CreationTimestamp.Time)
+}
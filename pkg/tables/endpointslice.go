@@ -0,0 +1,96 @@
+package tables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	discoveryv1 "k8s.io/api/discovery/v1"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     EndpointSliceTableName,
+		Resource: EndpointSliceResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: EndpointSliceTableName},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: EndpointSliceTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: EndpointSliceTableName},
+			{Name: "service", Type: sql.Text, Nullable: false, Source: EndpointSliceTableName},
+			{Name: "address_type", Type: sql.Text, Nullable: false, Source: EndpointSliceTableName},
+			{Name: "address", Type: sql.Text, Nullable: false, Source: EndpointSliceTableName},
+			{Name: "hostname", Type: sql.Text, Nullable: false, Source: EndpointSliceTableName},
+			{Name: "ready", Type: sql.Boolean, Nullable: true, Source: EndpointSliceTableName},
+			{Name: "serving", Type: sql.Boolean, Nullable: true, Source: EndpointSliceTableName},
+			{Name: "terminating", Type: sql.Boolean, Nullable: true, Source: EndpointSliceTableName},
+			{Name: "node", Type: sql.Text, Nullable: false, Source: EndpointSliceTableName},
+			{Name: "zone", Type: sql.Text, Nullable: false, Source: EndpointSliceTableName},
+			{Name: "target_kind", Type: sql.Text, Nullable: false, Source: EndpointSliceTableName},
+			{Name: "target_name", Type: sql.Text, Nullable: false, Source: EndpointSliceTableName},
+			{Name: "target_uid", Type: sql.Text, Nullable: false, Source: EndpointSliceTableName},
+			{Name: "portname", Type: sql.Text, Nullable: false, Source: EndpointSliceTableName},
+			{Name: "port", Type: sql.Int32, Nullable: true, Source: EndpointSliceTableName},
+			{Name: "protocol", Type: sql.Text, Nullable: false, Source: EndpointSliceTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: EndpointSliceTableName},
+		},
+		Rows: endpointSliceRows,
+	})
+}
+
+// endpointSliceRows emits a row per endpoint address and port. A slice
+// without ports exposes every port of its endpoints, which is reported with a
+// NULL port.
+func endpointSliceRows(resource interface{}) ([]sql.Row, error) {
+	slice, ok := resource.(*discoveryv1.EndpointSlice)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *discoveryv1.EndpointSlice but got %T", resource)
+	}
+
+	ports := slice.Ports
+	if len(ports) == 0 {
+		ports = []discoveryv1.EndpointPort{{}}
+	}
+
+	rows := []sql.Row{}
+	for _, endpoint := range slice.Endpoints {
+		for _, address := range endpoint.Addresses {
+			for _, port := range ports {
+				rows = append(rows, endpointSliceRow(slice, &endpoint, address, &port))
+			}
+		}
+	}
+	return rows, nil
+}
+
+func endpointSliceRow(slice *discoveryv1.EndpointSlice, endpoint *discoveryv1.Endpoint, address string, port *discoveryv1.EndpointPort) sql.Row {
+	targetKind, targetName, targetUID := "", "", ""
+	if endpoint.TargetRef != nil {
+		targetKind = endpoint.TargetRef.Kind
+		targetName = endpoint.TargetRef.Name
+		targetUID = string(endpoint.TargetRef.UID)
+	}
+
+	var portNumber interface{}
+	if port.Port != nil {
+		portNumber = *port.Port
+	}
+	protocol := ""
+	if port.Protocol != nil {
+		protocol = string(*port.Protocol)
+	}
+
+	return sql.NewRow(string(slice.UID), slice.Name, slice.Namespace,
+		slice.Labels[discoveryv1.LabelServiceName],
+		string(slice.AddressType),
+		address,
+		stringValue(endpoint.Hostname),
+		nullableBool(endpoint.Conditions.Ready),
+		nullableBool(endpoint.Conditions.Serving),
+		nullableBool(endpoint.Conditions.Terminating),
+		stringValue(endpoint.NodeName),
+		stringValue(endpoint.Zone),
+		targetKind, targetName, targetUID,
+		stringValue(port.Name),
+		portNumber,
+		protocol,
+		slice.CreationTimestamp.Time)
+}
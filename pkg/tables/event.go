@@ -0,0 +1,137 @@
+package tables
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/adalrsjr1/sqlcluster/internal/services"
+	"github.com/dolthub/go-mysql-server/sql"
+	v1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// DefaultEventRetention is how long events are kept after the API server
+// deletes them, unless the event table sets another Retention.
+const DefaultEventRetention = 6 * time.Hour
+
+func init() {
+	Register(TableProvider{
+		Name:     EventTableName,
+		Resource: EventResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: EventTableName},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: EventTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: EventTableName},
+			{Name: "involved_kind", Type: sql.Text, Nullable: false, Source: EventTableName},
+			{Name: "involved_name", Type: sql.Text, Nullable: false, Source: EventTableName},
+			{Name: "involved_namespace", Type: sql.Text, Nullable: false, Source: EventTableName},
+			{Name: "involved_uid", Type: sql.Text, Nullable: false, Source: EventTableName},
+			{Name: "reason", Type: sql.Text, Nullable: false, Source: EventTableName},
+			{Name: "message", Type: sql.Text, Nullable: false, Source: EventTableName},
+			{Name: "type", Type: sql.Text, Nullable: false, Source: EventTableName},
+			{Name: "count", Type: sql.Int32, Nullable: false, Source: EventTableName},
+			{Name: "first_timestamp", Type: sql.Datetime, Nullable: true, Source: EventTableName},
+			{Name: "last_timestamp", Type: sql.Datetime, Nullable: true, Source: EventTableName},
+			{Name: "reporting_controller", Type: sql.Text, Nullable: false, Source: EventTableName},
+			{Name: "node", Type: sql.Text, Nullable: false, Source: EventTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: EventTableName},
+		},
+		Rows:      eventRows,
+		Retention: DefaultEventRetention,
+	})
+}
+
+// eventInformer watches events.k8s.io/v1 when the API server serves it and
+// falls back to core/v1 otherwise. Only one of the APIs is watched: the table
+// relies on the API server exposing every event through both, as Kubernetes
+// stores them once, and lists no event served by the other API only.
+func eventInformer(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+	if _, err := services.Clientset.Discovery().ServerResourcesForGroupVersion(eventsv1.SchemeGroupVersion.String()); err != nil {
+		log.WithError(err).Infof("%s not served, watching core/v1 events", eventsv1.SchemeGroupVersion)
+		return factory.Core().V1().Events().Informer()
+	}
+	return factory.Events().V1().Events().Informer()
+}
+
+func eventRows(resource interface{}) ([]sql.Row, error) {
+	switch event := resource.(type) {
+	case *eventsv1.Event:
+		return []sql.Row{eventsV1Row(event)}, nil
+	case *v1.Event:
+		return []sql.Row{coreEventRow(event)}, nil
+	default:
+		return nil, fmt.Errorf("unexpected type for resource, expected *eventsv1.Event or *v1.Event but got %T", resource)
+	}
+}
+
+func eventsV1Row(event *eventsv1.Event) sql.Row {
+	count := event.DeprecatedCount
+	last := event.DeprecatedLastTimestamp.Time
+	if event.Series != nil {
+		count = event.Series.Count
+		last = event.Series.LastObservedTime.Time
+	}
+	first := event.DeprecatedFirstTimestamp.Time
+	if first.IsZero() {
+		first = event.EventTime.Time
+	}
+	if last.IsZero() {
+		last = first
+	}
+
+	controller := event.ReportingController
+	if controller == "" {
+		controller = event.DeprecatedSource.Component
+	}
+
+	return sql.NewRow(string(event.UID), event.Name, event.Namespace,
+		event.Regarding.Kind, event.Regarding.Name, event.Regarding.Namespace, string(event.Regarding.UID),
+		event.Reason, event.Note, event.Type,
+		eventCount(count),
+		nullableTime(first),
+		nullableTime(last),
+		controller,
+		event.DeprecatedSource.Host,
+		event.CreationTimestamp.Time)
+}
+
+func coreEventRow(event *v1.Event) sql.Row {
+	count := event.Count
+	last := event.LastTimestamp.Time
+	if event.Series != nil {
+		count = event.Series.Count
+		last = event.Series.LastObservedTime.Time
+	}
+	first := event.FirstTimestamp.Time
+	if first.IsZero() {
+		first = event.EventTime.Time
+	}
+	if last.IsZero() {
+		last = first
+	}
+
+	controller := event.ReportingController
+	if controller == "" {
+		controller = event.Source.Component
+	}
+
+	return sql.NewRow(string(event.UID), event.Name, event.Namespace,
+		event.InvolvedObject.Kind, event.InvolvedObject.Name, event.InvolvedObject.Namespace, string(event.InvolvedObject.UID),
+		event.Reason, event.Message, event.Type,
+		eventCount(count),
+		nullableTime(first),
+		nullableTime(last),
+		controller,
+		event.Source.Host,
+		event.CreationTimestamp.Time)
+}
+
+// eventCount counts an event reported without series or count once.
+func eventCount(count int32) int32 {
+	if count < 1 {
+		return 1
+	}
+	return count
+}
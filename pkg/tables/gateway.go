@@ -0,0 +1,166 @@
+package tables
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Gateway API objects are custom resources, so they are read from the
+// dynamic client as unstructured objects rather than typed structs.
+func init() {
+	Register(TableProvider{
+		Name:     GatewayTableName,
+		Resource: GatewayResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: GatewayTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: GatewayTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: GatewayTableName},
+			{Name: "gateway_class", Type: sql.Text, Nullable: false, Source: GatewayTableName},
+			{Name: "addresses", Type: sql.Text, Nullable: false, Source: GatewayTableName},
+			{Name: "listeners", Type: sql.JSON, Nullable: false, Source: GatewayTableName},
+			{Name: "labels", Type: sql.JSON, Nullable: false, Source: GatewayTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: GatewayTableName},
+		},
+		Rows: gatewayRows,
+	})
+
+	Register(TableProvider{
+		Name:     HTTPRouteTableName,
+		Resource: HTTPRouteResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: HTTPRouteTableName},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: HTTPRouteTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: HTTPRouteTableName},
+			{Name: "gateways", Type: sql.Text, Nullable: false, Source: HTTPRouteTableName},
+			{Name: "hostnames", Type: sql.Text, Nullable: false, Source: HTTPRouteTableName},
+			{Name: "rule", Type: sql.Int32, Nullable: false, Source: HTTPRouteTableName},
+			{Name: "matches", Type: sql.JSON, Nullable: false, Source: HTTPRouteTableName},
+			{Name: "backend_kind", Type: sql.Text, Nullable: true, Source: HTTPRouteTableName},
+			{Name: "backend_namespace", Type: sql.Text, Nullable: true, Source: HTTPRouteTableName},
+			{Name: "backend_name", Type: sql.Text, Nullable: true, Source: HTTPRouteTableName},
+			{Name: "backend_port", Type: sql.Int64, Nullable: true, Source: HTTPRouteTableName},
+			{Name: "weight", Type: sql.Int64, Nullable: true, Source: HTTPRouteTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: HTTPRouteTableName},
+		},
+		Rows: httpRouteRows,
+	})
+}
+
+func gatewayRows(resource interface{}) ([]sql.Row, error) {
+	gateway, ok := resource.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *unstructured.Unstructured but got %T", resource)
+	}
+
+	class, _, _ := unstructured.NestedString(gateway.Object, "spec", "gatewayClassName")
+	listeners, _, _ := unstructured.NestedSlice(gateway.Object, "spec", "listeners")
+	if listeners == nil {
+		listeners = []interface{}{}
+	}
+
+	statusAddresses, _, _ := unstructured.NestedSlice(gateway.Object, "status", "addresses")
+	addresses := make([]string, 0, len(statusAddresses))
+	for _, address := range statusAddresses {
+		if value, _, _ := unstructured.NestedString(asMap(address), "value"); value != "" {
+			addresses = append(addresses, value)
+		}
+	}
+
+	return []sql.Row{sql.NewRow(string(gateway.GetUID()), gateway.GetName(), gateway.GetNamespace(),
+		class,
+		strings.Join(addresses, ","),
+		sql.JSONDocument{Val: listeners},
+		mapJSON(gateway.GetLabels()),
+		gateway.GetCreationTimestamp().Time)}, nil
+}
+
+// httpRouteRows emits a row per backend of every rule of a route, rules
+// being numbered in declaration order. Rules without backends, such as
+// redirects, are emitted once with NULL backend columns. Backends default to
+// Services in the namespace of the route.
+func httpRouteRows(resource interface{}) ([]sql.Row, error) {
+	route, ok := resource.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *unstructured.Unstructured but got %T", resource)
+	}
+
+	parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	gateways := make([]string, 0, len(parentRefs))
+	for _, ref := range parentRefs {
+		name, _, _ := unstructured.NestedString(asMap(ref), "name")
+		namespace, _, _ := unstructured.NestedString(asMap(ref), "namespace")
+		if namespace == "" {
+			namespace = route.GetNamespace()
+		}
+		gateways = append(gateways, namespace+"/"+name)
+	}
+	hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	rows := []sql.Row{}
+	for i, rule := range rules {
+		matches, _, _ := unstructured.NestedSlice(asMap(rule), "matches")
+		if matches == nil {
+			matches = []interface{}{}
+		}
+		newRow := func(backend ...interface{}) sql.Row {
+			return append(sql.NewRow(string(route.GetUID()), route.GetName(), route.GetNamespace(),
+				strings.Join(gateways, ","),
+				strings.Join(hostnames, ","),
+				int32(i),
+				sql.JSONDocument{Val: matches}),
+				append(backend, route.GetCreationTimestamp().Time)...)
+		}
+
+		backendRefs, _, _ := unstructured.NestedSlice(asMap(rule), "backendRefs")
+		if len(backendRefs) == 0 {
+			rows = append(rows, newRow(nil, nil, nil, nil, nil))
+			continue
+		}
+		for _, ref := range backendRefs {
+			backend := asMap(ref)
+			kind, found, _ := unstructured.NestedString(backend, "kind")
+			if !found {
+				kind = "Service"
+			}
+			namespace, _, _ := unstructured.NestedString(backend, "namespace")
+			if namespace == "" {
+				namespace = route.GetNamespace()
+			}
+			name, _, _ := unstructured.NestedString(backend, "name")
+			rows = append(rows, newRow(kind, namespace, name,
+				nestedInt64(backend, "port"),
+				nestedInt64(backend, "weight")))
+		}
+	}
+	return rows, nil
+}
+
+func asMap(value interface{}) map[string]interface{} {
+	m, _ := value.(map[string]interface{})
+	return m
+}
+
+// nestedInt64 reads an integer field of an unstructured object, or NULL when
+// the field is absent. Numbers decoded from JSON may be int64 or float64.
+func nestedInt64(obj map[string]interface{}, fields ...string) interface{} {
+	value, found, err := unstructured.NestedFieldNoCopy(obj, fields...)
+	if err != nil || !found {
+		return nil
+	}
+	switch v := value.(type) {
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	case string:
+		if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return i
+		}
+	}
+	return nil
+}
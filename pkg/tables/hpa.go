@@ -0,0 +1,223 @@
+package tables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     HPATableName,
+		Resource: HPAResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: HPATableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: HPATableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: HPATableName},
+			{Name: "target_kind", Type: sql.Text, Nullable: false, Source: HPATableName},
+			{Name: "target_name", Type: sql.Text, Nullable: false, Source: HPATableName},
+			{Name: "min_replicas", Type: sql.Int32, Nullable: false, Source: HPATableName},
+			{Name: "max_replicas", Type: sql.Int32, Nullable: false, Source: HPATableName},
+			{Name: "current_replicas", Type: sql.Int32, Nullable: false, Source: HPATableName},
+			{Name: "desired_replicas", Type: sql.Int32, Nullable: false, Source: HPATableName},
+			{Name: "last_scale_time", Type: sql.Datetime, Nullable: true, Source: HPATableName},
+			{Name: "labels", Type: sql.JSON, Nullable: false, Source: HPATableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: HPATableName},
+		},
+		Rows: hpaRows,
+	})
+
+	Register(TableProvider{
+		Name:     HPAMetricTableName,
+		Resource: HPAResource,
+		Schema: sql.Schema{
+			{Name: "hpa_uid", Type: sql.Text, Nullable: false, Source: HPAMetricTableName},
+			{Name: "hpa", Type: sql.Text, Nullable: false, Source: HPAMetricTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: HPAMetricTableName},
+			{Name: "type", Type: sql.Text, Nullable: false, Source: HPAMetricTableName},
+			{Name: "metric", Type: sql.Text, Nullable: false, Source: HPAMetricTableName},
+			{Name: "container", Type: sql.Text, Nullable: false, Source: HPAMetricTableName},
+			{Name: "target_type", Type: sql.Text, Nullable: false, Source: HPAMetricTableName},
+			{Name: "target_value", Type: sql.Float64, Nullable: true, Source: HPAMetricTableName},
+			{Name: "target_utilization", Type: sql.Int32, Nullable: true, Source: HPAMetricTableName},
+			{Name: "current_value", Type: sql.Float64, Nullable: true, Source: HPAMetricTableName},
+			{Name: "current_utilization", Type: sql.Int32, Nullable: true, Source: HPAMetricTableName},
+		},
+		Rows: hpaMetricRows,
+	})
+
+	Register(TableProvider{
+		Name:     HPAConditionTableName,
+		Resource: HPAResource,
+		Schema: sql.Schema{
+			{Name: "hpa_uid", Type: sql.Text, Nullable: false, Source: HPAConditionTableName},
+			{Name: "hpa", Type: sql.Text, Nullable: false, Source: HPAConditionTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: HPAConditionTableName},
+			{Name: "type", Type: sql.Text, Nullable: false, Source: HPAConditionTableName},
+			{Name: "status", Type: sql.Text, Nullable: false, Source: HPAConditionTableName},
+			{Name: "reason", Type: sql.Text, Nullable: false, Source: HPAConditionTableName},
+			{Name: "message", Type: sql.Text, Nullable: false, Source: HPAConditionTableName},
+			{Name: "last_transition_time", Type: sql.Datetime, Nullable: true, Source: HPAConditionTableName},
+		},
+		Rows: hpaConditionRows,
+	})
+}
+
+func hpaRows(obj interface{}) ([]sql.Row, error) {
+	hpa, ok := obj.(*autoscalingv2.HorizontalPodAutoscaler)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *autoscalingv2.HorizontalPodAutoscaler but got %T", obj)
+	}
+
+	// the API server defaults minReplicas to 1
+	minReplicas := int32(1)
+	if hpa.Spec.MinReplicas != nil {
+		minReplicas = *hpa.Spec.MinReplicas
+	}
+
+	return []sql.Row{sql.NewRow(string(hpa.UID), hpa.Name, hpa.Namespace,
+		hpa.Spec.ScaleTargetRef.Kind,
+		hpa.Spec.ScaleTargetRef.Name,
+		minReplicas,
+		hpa.Spec.MaxReplicas,
+		hpa.Status.CurrentReplicas,
+		hpa.Status.DesiredReplicas,
+		nullableMetaTime(hpa.Status.LastScaleTime),
+		mapJSON(hpa.Labels),
+		hpa.CreationTimestamp.Time)}, nil
+}
+
+// hpaMetric identifies a metric of an autoscaler, so the current value
+// reported in its status can be matched to the spec declaring it.
+type hpaMetric struct {
+	metricType autoscalingv2.MetricSourceType
+	name       string
+	container  string
+}
+
+// hpaMetricRows emits a row per metric spec of an autoscaler along with its
+// current value. Resource values are in the units of the container table,
+// CPU in millicores and memory in bytes.
+func hpaMetricRows(obj interface{}) ([]sql.Row, error) {
+	hpa, ok := obj.(*autoscalingv2.HorizontalPodAutoscaler)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *autoscalingv2.HorizontalPodAutoscaler but got %T", obj)
+	}
+
+	current := map[hpaMetric]autoscalingv2.MetricValueStatus{}
+	for _, status := range hpa.Status.CurrentMetrics {
+		if metric, value, ok := hpaMetricStatus(&status); ok {
+			current[metric] = value
+		}
+	}
+
+	rows := make([]sql.Row, 0, len(hpa.Spec.Metrics))
+	for _, spec := range hpa.Spec.Metrics {
+		metric, target, ok := hpaMetricSpec(&spec)
+		if !ok {
+			continue
+		}
+
+		var currentValue, currentUtilization interface{}
+		if value, ok := current[metric]; ok {
+			currentValue = metricValue(metric, value.Value, value.AverageValue)
+			currentUtilization = nullableInt32(value.AverageUtilization)
+		}
+
+		rows = append(rows, sql.NewRow(string(hpa.UID), hpa.Name, hpa.Namespace,
+			string(metric.metricType),
+			metric.name,
+			metric.container,
+			string(target.Type),
+			metricValue(metric, target.Value, target.AverageValue),
+			nullableInt32(target.AverageUtilization),
+			currentValue,
+			currentUtilization))
+	}
+	return rows, nil
+}
+
+func hpaMetricSpec(spec *autoscalingv2.MetricSpec) (hpaMetric, autoscalingv2.MetricTarget, bool) {
+	metric := hpaMetric{metricType: spec.Type}
+	switch {
+	case spec.Resource != nil:
+		metric.name = string(spec.Resource.Name)
+		return metric, spec.Resource.Target, true
+	case spec.ContainerResource != nil:
+		metric.name = string(spec.ContainerResource.Name)
+		metric.container = spec.ContainerResource.Container
+		return metric, spec.ContainerResource.Target, true
+	case spec.Pods != nil:
+		metric.name = spec.Pods.Metric.Name
+		return metric, spec.Pods.Target, true
+	case spec.Object != nil:
+		metric.name = spec.Object.Metric.Name
+		return metric, spec.Object.Target, true
+	case spec.External != nil:
+		metric.name = spec.External.Metric.Name
+		return metric, spec.External.Target, true
+	}
+	return metric, autoscalingv2.MetricTarget{}, false
+}
+
+func hpaMetricStatus(status *autoscalingv2.MetricStatus) (hpaMetric, autoscalingv2.MetricValueStatus, bool) {
+	metric := hpaMetric{metricType: status.Type}
+	switch {
+	case status.Resource != nil:
+		metric.name = string(status.Resource.Name)
+		return metric, status.Resource.Current, true
+	case status.ContainerResource != nil:
+		metric.name = string(status.ContainerResource.Name)
+		metric.container = status.ContainerResource.Container
+		return metric, status.ContainerResource.Current, true
+	case status.Pods != nil:
+		metric.name = status.Pods.Metric.Name
+		return metric, status.Pods.Current, true
+	case status.Object != nil:
+		metric.name = status.Object.Metric.Name
+		return metric, status.Object.Current, true
+	case status.External != nil:
+		metric.name = status.External.Metric.Name
+		return metric, status.External.Current, true
+	}
+	return metric, autoscalingv2.MetricValueStatus{}, false
+}
+
+// metricValue returns the value or else the average value of a metric,
+// converted like quantityValue but keeping the fraction of custom metrics.
+func metricValue(metric hpaMetric, value, averageValue *resource.Quantity) interface{} {
+	q := value
+	if q == nil {
+		q = averageValue
+	}
+	if q == nil {
+		return nil
+	}
+	resourceMetric := metric.metricType == autoscalingv2.ResourceMetricSourceType ||
+		metric.metricType == autoscalingv2.ContainerResourceMetricSourceType
+	if resourceMetric && v1.ResourceName(metric.name) == v1.ResourceCPU {
+		return float64(q.MilliValue())
+	}
+	return q.AsApproximateFloat64()
+}
+
+func hpaConditionRows(obj interface{}) ([]sql.Row, error) {
+	hpa, ok := obj.(*autoscalingv2.HorizontalPodAutoscaler)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *autoscalingv2.HorizontalPodAutoscaler but got %T", obj)
+	}
+
+	rows := make([]sql.Row, 0, len(hpa.Status.Conditions))
+	for _, condition := range hpa.Status.Conditions {
+		rows = append(rows, sql.NewRow(string(hpa.UID), hpa.Name, hpa.Namespace,
+			string(condition.Type),
+			string(condition.Status),
+			condition.Reason,
+			condition.Message,
+			nullableTime(condition.LastTransitionTime.Time)))
+	}
+	return rows, nil
+}
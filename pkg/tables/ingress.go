@@ -0,0 +1,134 @@
+package tables
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+const ingressClassAnnotation = "kubernetes.io/ingress.class"
+
+func init() {
+	Register(TableProvider{
+		Name:     IngressTableName,
+		Resource: IngressResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: IngressTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: IngressTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: IngressTableName},
+			{Name: "class", Type: sql.Text, Nullable: false, Source: IngressTableName},
+			{Name: "default_service", Type: sql.Text, Nullable: false, Source: IngressTableName},
+			{Name: "default_port", Type: sql.Text, Nullable: false, Source: IngressTableName},
+			{Name: "addresses", Type: sql.Text, Nullable: false, Source: IngressTableName},
+			{Name: "labels", Type: sql.JSON, Nullable: false, Source: IngressTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: IngressTableName},
+		},
+		Rows: ingressRows,
+	})
+
+	Register(TableProvider{
+		Name:     IngressRuleTableName,
+		Resource: IngressResource,
+		Schema: sql.Schema{
+			{Name: "ingress_uid", Type: sql.Text, Nullable: false, Source: IngressRuleTableName},
+			{Name: "ingress", Type: sql.Text, Nullable: false, Source: IngressRuleTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: IngressRuleTableName},
+			{Name: "host", Type: sql.Text, Nullable: false, Source: IngressRuleTableName},
+			{Name: "path", Type: sql.Text, Nullable: false, Source: IngressRuleTableName},
+			{Name: "path_type", Type: sql.Text, Nullable: false, Source: IngressRuleTableName},
+			{Name: "service", Type: sql.Text, Nullable: false, Source: IngressRuleTableName},
+			{Name: "port", Type: sql.Text, Nullable: false, Source: IngressRuleTableName},
+			{Name: "tls_secret", Type: sql.Text, Nullable: false, Source: IngressRuleTableName},
+		},
+		Rows: ingressRuleRows,
+	})
+}
+
+func ingressRows(resource interface{}) ([]sql.Row, error) {
+	ingress, ok := resource.(*networkingv1.Ingress)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *networkingv1.Ingress but got %T", resource)
+	}
+
+	class := ingress.Annotations[ingressClassAnnotation]
+	if ingress.Spec.IngressClassName != nil {
+		class = *ingress.Spec.IngressClassName
+	}
+
+	addresses := []string{}
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		if lb.IP != "" {
+			addresses = append(addresses, lb.IP)
+		} else if lb.Hostname != "" {
+			addresses = append(addresses, lb.Hostname)
+		}
+	}
+
+	service, port := ingressBackend(ingress.Spec.DefaultBackend)
+	return []sql.Row{sql.NewRow(string(ingress.UID), ingress.Name, ingress.Namespace,
+		class,
+		service,
+		port,
+		strings.Join(addresses, ","),
+		mapJSON(ingress.Labels),
+		ingress.CreationTimestamp.Time)}, nil
+}
+
+// ingressRuleRows emits a row per host and path routed by an ingress, along
+// with the TLS secret terminating the host, if any.
+func ingressRuleRows(resource interface{}) ([]sql.Row, error) {
+	ingress, ok := resource.(*networkingv1.Ingress)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *networkingv1.Ingress but got %T", resource)
+	}
+
+	rows := []sql.Row{}
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		tlsSecret := ingressTLSSecret(ingress, rule.Host)
+		for _, path := range rule.HTTP.Paths {
+			pathType := ""
+			if path.PathType != nil {
+				pathType = string(*path.PathType)
+			}
+			service, port := ingressBackend(&path.Backend)
+			rows = append(rows, sql.NewRow(string(ingress.UID), ingress.Name, ingress.Namespace,
+				rule.Host,
+				path.Path,
+				pathType,
+				service,
+				port,
+				tlsSecret))
+		}
+	}
+	return rows, nil
+}
+
+// ingressBackend returns the service and port, by number or name, of a
+// backend. Resource backends have no service.
+func ingressBackend(backend *networkingv1.IngressBackend) (string, string) {
+	if backend == nil || backend.Service == nil {
+		return "", ""
+	}
+	port := backend.Service.Port.Name
+	if backend.Service.Port.Number != 0 {
+		port = strconv.Itoa(int(backend.Service.Port.Number))
+	}
+	return backend.Service.Name, port
+}
+
+func ingressTLSSecret(ingress *networkingv1.Ingress, host string) string {
+	for _, tls := range ingress.Spec.TLS {
+		for _, tlsHost := range tls.Hosts {
+			if tlsHost == host {
+				return tls.SecretName
+			}
+		}
+	}
+	return ""
+}
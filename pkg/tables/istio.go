@@ -0,0 +1,342 @@
+package tables
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Istio configuration objects are custom resources, so they are read from
+// the dynamic client as unstructured objects, like the Gateway API ones.
+func init() {
+	Register(TableProvider{
+		Name:     VirtualServiceRouteTableName,
+		Resource: VirtualServiceResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: VirtualServiceRouteTableName},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: VirtualServiceRouteTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: VirtualServiceRouteTableName},
+			{Name: "hosts", Type: sql.Text, Nullable: false, Source: VirtualServiceRouteTableName},
+			{Name: "gateways", Type: sql.Text, Nullable: false, Source: VirtualServiceRouteTableName},
+			{Name: "protocol", Type: sql.Text, Nullable: false, Source: VirtualServiceRouteTableName},
+			{Name: "route", Type: sql.Int32, Nullable: false, Source: VirtualServiceRouteTableName},
+			{Name: "route_name", Type: sql.Text, Nullable: false, Source: VirtualServiceRouteTableName},
+			{Name: "match_rules", Type: sql.JSON, Nullable: true, Source: VirtualServiceRouteTableName},
+			{Name: "destination_host", Type: sql.Text, Nullable: true, Source: VirtualServiceRouteTableName},
+			{Name: "destination_service", Type: sql.Text, Nullable: true, Source: VirtualServiceRouteTableName},
+			{Name: "destination_subset", Type: sql.Text, Nullable: true, Source: VirtualServiceRouteTableName},
+			{Name: "destination_port", Type: sql.Int64, Nullable: true, Source: VirtualServiceRouteTableName},
+			{Name: "weight", Type: sql.Int64, Nullable: true, Source: VirtualServiceRouteTableName},
+			{Name: "timeout", Type: sql.Text, Nullable: true, Source: VirtualServiceRouteTableName},
+			{Name: "retries", Type: sql.JSON, Nullable: true, Source: VirtualServiceRouteTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: VirtualServiceRouteTableName},
+		},
+		Rows:    virtualServiceRouteRows,
+		Lookups: []Resource{NamespaceResource},
+	})
+
+	Register(TableProvider{
+		Name:     DestinationRuleTableName,
+		Resource: DestinationRuleResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: DestinationRuleTableName},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: DestinationRuleTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: DestinationRuleTableName},
+			{Name: "host", Type: sql.Text, Nullable: false, Source: DestinationRuleTableName},
+			{Name: "subset", Type: sql.Text, Nullable: true, Source: DestinationRuleTableName},
+			{Name: "subset_labels", Type: sql.JSON, Nullable: true, Source: DestinationRuleTableName},
+			{Name: "load_balancer", Type: sql.Text, Nullable: true, Source: DestinationRuleTableName},
+			{Name: "tls_mode", Type: sql.Text, Nullable: true, Source: DestinationRuleTableName},
+			{Name: "traffic_policy", Type: sql.JSON, Nullable: true, Source: DestinationRuleTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: DestinationRuleTableName},
+		},
+		Rows: destinationRuleRows,
+	})
+
+	Register(TableProvider{
+		Name:     ServiceEntryTableName,
+		Resource: ServiceEntryResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: ServiceEntryTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: ServiceEntryTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: ServiceEntryTableName},
+			{Name: "hosts", Type: sql.Text, Nullable: false, Source: ServiceEntryTableName},
+			{Name: "addresses", Type: sql.Text, Nullable: false, Source: ServiceEntryTableName},
+			{Name: "location", Type: sql.Text, Nullable: false, Source: ServiceEntryTableName},
+			{Name: "resolution", Type: sql.Text, Nullable: false, Source: ServiceEntryTableName},
+			{Name: "ports", Type: sql.JSON, Nullable: true, Source: ServiceEntryTableName},
+			{Name: "endpoints", Type: sql.JSON, Nullable: true, Source: ServiceEntryTableName},
+			{Name: "export_to", Type: sql.Text, Nullable: false, Source: ServiceEntryTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: ServiceEntryTableName},
+		},
+		Rows: serviceEntryRows,
+	})
+
+	Register(TableProvider{
+		Name:     PeerAuthenticationTableName,
+		Resource: PeerAuthenticationResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: PeerAuthenticationTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: PeerAuthenticationTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: PeerAuthenticationTableName},
+			{Name: "selector", Type: sql.Text, Nullable: false, Source: PeerAuthenticationTableName},
+			{Name: "mtls_mode", Type: sql.Text, Nullable: false, Source: PeerAuthenticationTableName},
+			{Name: "port_level_mtls", Type: sql.JSON, Nullable: true, Source: PeerAuthenticationTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: PeerAuthenticationTableName},
+		},
+		Rows: peerAuthenticationRows,
+	})
+
+	Register(TableProvider{
+		Name:     AuthorizationPolicyTableName,
+		Resource: AuthorizationPolicyResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: AuthorizationPolicyTableName},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: AuthorizationPolicyTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: AuthorizationPolicyTableName},
+			{Name: "selector", Type: sql.Text, Nullable: false, Source: AuthorizationPolicyTableName},
+			{Name: "action", Type: sql.Text, Nullable: false, Source: AuthorizationPolicyTableName},
+			{Name: "rule", Type: sql.Int32, Nullable: true, Source: AuthorizationPolicyTableName},
+			{Name: "from_sources", Type: sql.JSON, Nullable: true, Source: AuthorizationPolicyTableName},
+			{Name: "to_operations", Type: sql.JSON, Nullable: true, Source: AuthorizationPolicyTableName},
+			{Name: "when_conditions", Type: sql.JSON, Nullable: true, Source: AuthorizationPolicyTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: AuthorizationPolicyTableName},
+		},
+		Rows: authorizationPolicyRows,
+	})
+}
+
+// istioVersions lists the versions Istio served a resource with, newest
+// first.
+func istioVersions(group, resource string) []schema.GroupVersionResource {
+	versions := []schema.GroupVersionResource{}
+	for _, version := range []string{"v1", "v1beta1", "v1alpha3"} {
+		versions = append(versions, schema.GroupVersionResource{Group: group, Version: version, Resource: resource})
+	}
+	return versions
+}
+
+// virtualServiceRouteRows emits a row per destination of every http, tls and
+// tcp route of a virtual service, routes being numbered in declaration order
+// per protocol. Routes without destinations, such as redirects, are emitted
+// once with NULL destination columns. A lone destination without weight
+// receives all the traffic.
+func virtualServiceRouteRows(resource interface{}) ([]sql.Row, error) {
+	vs, ok := resource.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *unstructured.Unstructured but got %T", resource)
+	}
+
+	hosts, _, _ := unstructured.NestedStringSlice(vs.Object, "spec", "hosts")
+	gateways, _, _ := unstructured.NestedStringSlice(vs.Object, "spec", "gateways")
+
+	rows := []sql.Row{}
+	for _, protocol := range []string{"http", "tls", "tcp"} {
+		routes, _, _ := unstructured.NestedSlice(vs.Object, "spec", protocol)
+		for i, r := range routes {
+			route := asMap(r)
+			name, _, _ := unstructured.NestedString(route, "name")
+			newRow := func(destination ...interface{}) sql.Row {
+				row := sql.NewRow(string(vs.GetUID()), vs.GetName(), vs.GetNamespace(),
+					strings.Join(hosts, ","),
+					strings.Join(gateways, ","),
+					protocol,
+					int32(i),
+					name,
+					nestedJSON(route, "match"))
+				row = append(row, destination...)
+				return append(row,
+					nestedValue(route, "timeout"),
+					nestedJSON(route, "retries"),
+					vs.GetCreationTimestamp().Time)
+			}
+
+			destinations, _, _ := unstructured.NestedSlice(route, "route")
+			if len(destinations) == 0 {
+				rows = append(rows, newRow(nil, nil, nil, nil, nil))
+				continue
+			}
+			for _, d := range destinations {
+				destination := asMap(d)
+				host, _, _ := unstructured.NestedString(destination, "destination", "host")
+				weight := nestedInt64(destination, "weight")
+				if weight == nil && len(destinations) == 1 {
+					weight = int64(100)
+				}
+				rows = append(rows, newRow(host,
+					istioServiceName(host, vs.GetNamespace()),
+					nestedValue(destination, "destination", "subset"),
+					nestedInt64(destination, "destination", "port", "number"),
+					weight))
+			}
+		}
+	}
+	return rows, nil
+}
+
+const clusterDomain = "svc.cluster.local"
+
+// istioServiceName qualifies a service host the way Istio does, so it matches
+// the destination services reported by Istio telemetry. Short names are in
+// the namespace of the declaring object, and name.namespace or
+// name.namespace.svc hosts are completed with the default cluster domain.
+// Other hosts, fully qualified or external, are returned unchanged.
+func istioServiceName(host, namespace string) string {
+	labels := strings.Split(host, ".")
+	switch {
+	case len(labels) == 1:
+		return host + "." + namespace + "." + clusterDomain
+	case len(labels) == 2 && isNamespace(labels[1]):
+		return host + "." + clusterDomain
+	case len(labels) == 3 && labels[2] == "svc":
+		return host + strings.TrimPrefix(clusterDomain, "svc")
+	}
+	return host
+}
+
+// isNamespace tells apart name.namespace hosts from external domains.
+func isNamespace(name string) bool {
+	store, ok := LookupCache(NamespaceResource)
+	if !ok {
+		return false
+	}
+	_, exists, err := store.GetByKey(name)
+	return err == nil && exists
+}
+
+// destinationRuleRows emits a row for the traffic policy of a destination
+// rule, with a NULL subset, and a row per subset with the policy overriding
+// it, if any.
+func destinationRuleRows(resource interface{}) ([]sql.Row, error) {
+	rule, ok := resource.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *unstructured.Unstructured but got %T", resource)
+	}
+
+	host, _, _ := unstructured.NestedString(rule.Object, "spec", "host")
+	newRow := func(subset, subsetLabels interface{}, policy map[string]interface{}) sql.Row {
+		return sql.NewRow(string(rule.GetUID()), rule.GetName(), rule.GetNamespace(),
+			host,
+			subset,
+			subsetLabels,
+			nestedValue(policy, "loadBalancer", "simple"),
+			nestedValue(policy, "tls", "mode"),
+			jsonOrNull(policy),
+			rule.GetCreationTimestamp().Time)
+	}
+
+	policy, _, _ := unstructured.NestedMap(rule.Object, "spec", "trafficPolicy")
+	rows := []sql.Row{newRow(nil, nil, policy)}
+
+	subsets, _, _ := unstructured.NestedSlice(rule.Object, "spec", "subsets")
+	for _, s := range subsets {
+		subset := asMap(s)
+		subsetPolicy, _, _ := unstructured.NestedMap(subset, "trafficPolicy")
+		rows = append(rows, newRow(nestedValue(subset, "name"), nestedJSON(subset, "labels"), subsetPolicy))
+	}
+	return rows, nil
+}
+
+func serviceEntryRows(resource interface{}) ([]sql.Row, error) {
+	entry, ok := resource.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *unstructured.Unstructured but got %T", resource)
+	}
+
+	hosts, _, _ := unstructured.NestedStringSlice(entry.Object, "spec", "hosts")
+	addresses, _, _ := unstructured.NestedStringSlice(entry.Object, "spec", "addresses")
+	location, _, _ := unstructured.NestedString(entry.Object, "spec", "location")
+	resolution, _, _ := unstructured.NestedString(entry.Object, "spec", "resolution")
+	exportTo, _, _ := unstructured.NestedStringSlice(entry.Object, "spec", "exportTo")
+
+	return []sql.Row{sql.NewRow(string(entry.GetUID()), entry.GetName(), entry.GetNamespace(),
+		strings.Join(hosts, ","),
+		strings.Join(addresses, ","),
+		location,
+		resolution,
+		nestedJSON(entry.Object, "spec", "ports"),
+		nestedJSON(entry.Object, "spec", "endpoints"),
+		strings.Join(exportTo, ","),
+		entry.GetCreationTimestamp().Time)}, nil
+}
+
+func peerAuthenticationRows(resource interface{}) ([]sql.Row, error) {
+	auth, ok := resource.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *unstructured.Unstructured but got %T", resource)
+	}
+
+	mode, _, _ := unstructured.NestedString(auth.Object, "spec", "mtls", "mode")
+	if mode == "" {
+		mode = "UNSET"
+	}
+
+	return []sql.Row{sql.NewRow(string(auth.GetUID()), auth.GetName(), auth.GetNamespace(),
+		workloadSelector(auth.Object),
+		mode,
+		nestedJSON(auth.Object, "spec", "portLevelMtls"),
+		auth.GetCreationTimestamp().Time)}, nil
+}
+
+// authorizationPolicyRows emits a row per rule of a policy. Policies without
+// rules, which match nothing when allowing, are emitted once with NULL rule
+// columns.
+func authorizationPolicyRows(resource interface{}) ([]sql.Row, error) {
+	policy, ok := resource.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *unstructured.Unstructured but got %T", resource)
+	}
+
+	action, _, _ := unstructured.NestedString(policy.Object, "spec", "action")
+	if action == "" {
+		action = "ALLOW"
+	}
+	selector := workloadSelector(policy.Object)
+	newRow := func(rule ...interface{}) sql.Row {
+		row := sql.NewRow(string(policy.GetUID()), policy.GetName(), policy.GetNamespace(), selector, action)
+		return append(append(row, rule...), policy.GetCreationTimestamp().Time)
+	}
+
+	rules, _, _ := unstructured.NestedSlice(policy.Object, "spec", "rules")
+	if len(rules) == 0 {
+		return []sql.Row{newRow(nil, nil, nil, nil)}, nil
+	}
+
+	rows := make([]sql.Row, 0, len(rules))
+	for i, r := range rules {
+		rule := asMap(r)
+		rows = append(rows, newRow(int32(i),
+			nestedJSON(rule, "from"),
+			nestedJSON(rule, "to"),
+			nestedJSON(rule, "when")))
+	}
+	return rows, nil
+}
+
+// workloadSelector formats the matchLabels of an Istio workload selector
+// like a label selector. Policies without selector apply to the namespace.
+func workloadSelector(obj map[string]interface{}) string {
+	matchLabels, _, _ := unstructured.NestedStringMap(obj, "spec", "selector", "matchLabels")
+	return labels.SelectorFromSet(matchLabels).String()
+}
+
+// nestedValue reads a scalar field of an unstructured object, or NULL when
+// the field is absent.
+func nestedValue(obj map[string]interface{}, fields ...string) interface{} {
+	value, found, err := unstructured.NestedFieldNoCopy(obj, fields...)
+	if err != nil || !found {
+		return nil
+	}
+	return value
+}
+
+func jsonOrNull(value map[string]interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	return sql.JSONDocument{Val: value}
+}
@@ -0,0 +1,162 @@
+package tables
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultJobRetention is how long finished jobs are kept after the API server
+// deletes them, unless the job table sets another Retention.
+const DefaultJobRetention = 24 * time.Hour
+
+func init() {
+	Register(TableProvider{
+		Name:     JobTableName,
+		Resource: JobResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: JobTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: JobTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: JobTableName},
+			{Name: "cronjob", Type: sql.Text, Nullable: false, Source: JobTableName},
+			{Name: "owner_uid", Type: sql.Text, Nullable: false, Source: JobTableName},
+			{Name: "status", Type: sql.Text, Nullable: false, Source: JobTableName},
+			{Name: "completions", Type: sql.Int32, Nullable: true, Source: JobTableName},
+			{Name: "parallelism", Type: sql.Int32, Nullable: true, Source: JobTableName},
+			{Name: "completion_mode", Type: sql.Text, Nullable: false, Source: JobTableName},
+			{Name: "active", Type: sql.Int32, Nullable: false, Source: JobTableName},
+			{Name: "succeeded", Type: sql.Int32, Nullable: false, Source: JobTableName},
+			{Name: "failed", Type: sql.Int32, Nullable: false, Source: JobTableName},
+			{Name: "backoff_limit", Type: sql.Int32, Nullable: true, Source: JobTableName},
+			{Name: "active_deadline_seconds", Type: sql.Int64, Nullable: true, Source: JobTableName},
+			{Name: "suspend", Type: sql.Boolean, Nullable: false, Source: JobTableName},
+			{Name: "start_time", Type: sql.Datetime, Nullable: true, Source: JobTableName},
+			{Name: "completion_time", Type: sql.Datetime, Nullable: true, Source: JobTableName},
+			{Name: "labels", Type: sql.JSON, Nullable: false, Source: JobTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: JobTableName},
+		},
+		Rows:      jobRows,
+		Retention: DefaultJobRetention,
+		Retain:    finishedJob,
+	})
+
+	Register(TableProvider{
+		Name:     CronJobTableName,
+		Resource: CronJobResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: CronJobTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: CronJobTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: CronJobTableName},
+			{Name: "schedule", Type: sql.Text, Nullable: false, Source: CronJobTableName},
+			{Name: "time_zone", Type: sql.Text, Nullable: false, Source: CronJobTableName},
+			{Name: "suspend", Type: sql.Boolean, Nullable: false, Source: CronJobTableName},
+			{Name: "concurrency_policy", Type: sql.Text, Nullable: false, Source: CronJobTableName},
+			{Name: "active", Type: sql.Int32, Nullable: false, Source: CronJobTableName},
+			{Name: "last_schedule_time", Type: sql.Datetime, Nullable: true, Source: CronJobTableName},
+			{Name: "last_successful_time", Type: sql.Datetime, Nullable: true, Source: CronJobTableName},
+			{Name: "successful_jobs_history_limit", Type: sql.Int32, Nullable: true, Source: CronJobTableName},
+			{Name: "failed_jobs_history_limit", Type: sql.Int32, Nullable: true, Source: CronJobTableName},
+			{Name: "labels", Type: sql.JSON, Nullable: false, Source: CronJobTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: CronJobTableName},
+		},
+		Rows: cronJobRows,
+	})
+}
+
+func jobRows(resource interface{}) ([]sql.Row, error) {
+	job, ok := resource.(*batchv1.Job)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *batchv1.Job but got %T", resource)
+	}
+	return []sql.Row{jobRow(job)}, nil
+}
+
+func jobRow(job *batchv1.Job) sql.Row {
+	cronJob := ""
+	if controller := metav1.GetControllerOfNoCopy(job); controller != nil && controller.Kind == "CronJob" {
+		cronJob = controller.Name
+	}
+
+	completionMode := batchv1.NonIndexedCompletion
+	if job.Spec.CompletionMode != nil {
+		completionMode = *job.Spec.CompletionMode
+	}
+
+	var activeDeadline interface{}
+	if job.Spec.ActiveDeadlineSeconds != nil {
+		activeDeadline = *job.Spec.ActiveDeadlineSeconds
+	}
+
+	return sql.NewRow(string(job.UID), job.Name, job.Namespace,
+		cronJob,
+		controllerUID(&job.ObjectMeta),
+		jobStatus(job),
+		nullableInt32(job.Spec.Completions),
+		nullableInt32(job.Spec.Parallelism),
+		string(completionMode),
+		job.Status.Active,
+		job.Status.Succeeded,
+		job.Status.Failed,
+		nullableInt32(job.Spec.BackoffLimit),
+		activeDeadline,
+		boolValue(job.Spec.Suspend != nil && *job.Spec.Suspend),
+		nullableMetaTime(job.Status.StartTime),
+		nullableMetaTime(job.Status.CompletionTime),
+		mapJSON(job.Labels),
+		job.CreationTimestamp.Time)
+}
+
+// jobStatus summarizes a job the way kubectl does: Complete or Failed once
+// the job finished, Suspended or Running otherwise.
+func jobStatus(job *batchv1.Job) string {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != v1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete, batchv1.JobFailed, batchv1.JobSuspended:
+			return string(condition.Type)
+		}
+	}
+	return "Running"
+}
+
+// finishedJob retains deleted jobs that completed or failed. Jobs deleted
+// while running are dropped along with their pods.
+func finishedJob(obj interface{}) bool {
+	job, ok := obj.(*batchv1.Job)
+	if !ok {
+		return false
+	}
+	status := jobStatus(job)
+	return status == string(batchv1.JobComplete) || status == string(batchv1.JobFailed)
+}
+
+func cronJobRows(resource interface{}) ([]sql.Row, error) {
+	cronJob, ok := resource.(*batchv1.CronJob)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *batchv1.CronJob but got %T", resource)
+	}
+
+	concurrencyPolicy := cronJob.Spec.ConcurrencyPolicy
+	if concurrencyPolicy == "" {
+		concurrencyPolicy = batchv1.AllowConcurrent
+	}
+
+	return []sql.Row{sql.NewRow(string(cronJob.UID), cronJob.Name, cronJob.Namespace,
+		cronJob.Spec.Schedule,
+		stringValue(cronJob.Spec.TimeZone),
+		boolValue(cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend),
+		string(concurrencyPolicy),
+		int32(len(cronJob.Status.Active)),
+		nullableMetaTime(cronJob.Status.LastScheduleTime),
+		nullableMetaTime(cronJob.Status.LastSuccessfulTime),
+		nullableInt32(cronJob.Spec.SuccessfulJobsHistoryLimit),
+		nullableInt32(cronJob.Spec.FailedJobsHistoryLimit),
+		mapJSON(cronJob.Labels),
+		cronJob.CreationTimestamp.Time)}, nil
+}
@@ -0,0 +1,74 @@
+package tables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     LabelTableName,
+		Resource: AnyResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: LabelTableName},
+			{Name: "kind", Type: sql.Text, Nullable: false, Source: LabelTableName},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: LabelTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: LabelTableName},
+			{Name: "label_key", Type: sql.Text, Nullable: false, Source: LabelTableName},
+			{Name: "value", Type: sql.Text, Nullable: false, Source: LabelTableName},
+		},
+		Rows: labelRows,
+	})
+
+	Register(TableProvider{
+		Name:     AnnotationTableName,
+		Resource: AnyResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: AnnotationTableName},
+			{Name: "kind", Type: sql.Text, Nullable: false, Source: AnnotationTableName},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: AnnotationTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: AnnotationTableName},
+			{Name: "annotation_key", Type: sql.Text, Nullable: false, Source: AnnotationTableName},
+			{Name: "value", Type: sql.Text, Nullable: false, Source: AnnotationTableName},
+		},
+		Rows: annotationRows,
+	})
+}
+
+func labelRows(resource interface{}) ([]sql.Row, error) {
+	obj, err := meta.Accessor(resource)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected type for resource, expected metav1.Object but got %T", resource)
+	}
+	return keyValueRows(resource, obj, obj.GetLabels()), nil
+}
+
+func annotationRows(resource interface{}) ([]sql.Row, error) {
+	obj, err := meta.Accessor(resource)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected type for resource, expected metav1.Object but got %T", resource)
+	}
+	return keyValueRows(resource, obj, obj.GetAnnotations()), nil
+}
+
+func keyValueRows(resource interface{}, obj metav1.Object, pairs map[string]string) []sql.Row {
+	kind := objectKind(resource)
+	rows := make([]sql.Row, 0, len(pairs))
+	for key, value := range pairs {
+		rows = append(rows, sql.NewRow(string(obj.GetUID()), kind, obj.GetName(), obj.GetNamespace(), key, value))
+	}
+	return rows
+}
+
+// mapJSON exposes a string map, such as labels, as a JSON object usable with
+// JSON_EXTRACT.
+func mapJSON(m map[string]string) sql.JSONDocument {
+	doc := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		doc[key] = value
+	}
+	return sql.JSONDocument{Val: doc}
+}
@@ -0,0 +1,34 @@
+package tables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	v1 "k8s.io/api/core/v1"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     NamespaceTableName,
+		Resource: NamespaceResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: NamespaceTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: NamespaceTableName},
+			{Name: "phase", Type: sql.Text, Nullable: false, Source: NamespaceTableName},
+			{Name: "labels", Type: sql.JSON, Nullable: false, Source: NamespaceTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: NamespaceTableName},
+		},
+		Rows: namespaceRows,
+	})
+}
+
+func namespaceRows(resource interface{}) ([]sql.Row, error) {
+	namespace, ok := resource.(*v1.Namespace)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *v1.Namespace but got %T", resource)
+	}
+	return []sql.Row{sql.NewRow(string(namespace.UID), namespace.Name,
+		string(namespace.Status.Phase),
+		mapJSON(namespace.Labels),
+		namespace.CreationTimestamp.Time)}, nil
+}
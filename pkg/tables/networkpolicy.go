@@ -0,0 +1,156 @@
+package tables
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     NetworkPolicyTableName,
+		Resource: NetworkPolicyResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: NetworkPolicyTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: NetworkPolicyTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: NetworkPolicyTableName},
+			{Name: "pod_selector", Type: sql.Text, Nullable: false, Source: NetworkPolicyTableName},
+			{Name: "policy_types", Type: sql.Text, Nullable: false, Source: NetworkPolicyTableName},
+			{Name: "labels", Type: sql.JSON, Nullable: false, Source: NetworkPolicyTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: NetworkPolicyTableName},
+		},
+		Rows: networkPolicyRows,
+	})
+
+	Register(TableProvider{
+		Name:     NetworkPolicyRuleTableName,
+		Resource: NetworkPolicyResource,
+		Schema: sql.Schema{
+			{Name: "policy_uid", Type: sql.Text, Nullable: false, Source: NetworkPolicyRuleTableName},
+			{Name: "policy", Type: sql.Text, Nullable: false, Source: NetworkPolicyRuleTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: NetworkPolicyRuleTableName},
+			{Name: "direction", Type: sql.Text, Nullable: false, Source: NetworkPolicyRuleTableName},
+			{Name: "rule", Type: sql.Int32, Nullable: false, Source: NetworkPolicyRuleTableName},
+			{Name: "peer_pod_selector", Type: sql.Text, Nullable: true, Source: NetworkPolicyRuleTableName},
+			{Name: "peer_namespace_selector", Type: sql.Text, Nullable: true, Source: NetworkPolicyRuleTableName},
+			{Name: "ip_block", Type: sql.Text, Nullable: true, Source: NetworkPolicyRuleTableName},
+			{Name: "ip_block_except", Type: sql.Text, Nullable: true, Source: NetworkPolicyRuleTableName},
+			{Name: "protocol", Type: sql.Text, Nullable: true, Source: NetworkPolicyRuleTableName},
+			{Name: "port", Type: sql.Text, Nullable: true, Source: NetworkPolicyRuleTableName},
+			{Name: "end_port", Type: sql.Int32, Nullable: true, Source: NetworkPolicyRuleTableName},
+		},
+		Rows: networkPolicyRuleRows,
+	})
+}
+
+// networkPolicyTypes resolves the policy types of a policy that declares
+// none the way the API server does: Ingress, plus Egress when it has egress
+// rules.
+func networkPolicyTypes(policy *networkingv1.NetworkPolicy) []string {
+	types := []string{}
+	for _, policyType := range policy.Spec.PolicyTypes {
+		types = append(types, string(policyType))
+	}
+	if len(types) > 0 {
+		return types
+	}
+
+	types = append(types, string(networkingv1.PolicyTypeIngress))
+	if len(policy.Spec.Egress) > 0 {
+		types = append(types, string(networkingv1.PolicyTypeEgress))
+	}
+	return types
+}
+
+func networkPolicyRows(resource interface{}) ([]sql.Row, error) {
+	policy, ok := resource.(*networkingv1.NetworkPolicy)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *networkingv1.NetworkPolicy but got %T", resource)
+	}
+
+	return []sql.Row{sql.NewRow(string(policy.UID), policy.Name, policy.Namespace,
+		networkPolicySelector(&policy.Spec.PodSelector),
+		strings.Join(networkPolicyTypes(policy), ","),
+		mapJSON(policy.Labels),
+		policy.CreationTimestamp.Time)}, nil
+}
+
+// networkPolicyRuleRows emits a row per peer and port of every ingress and
+// egress rule, rules being numbered in declaration order per direction. An
+// empty peer list allows every peer and an empty port list every port; both
+// are emitted as NULL columns.
+func networkPolicyRuleRows(resource interface{}) ([]sql.Row, error) {
+	policy, ok := resource.(*networkingv1.NetworkPolicy)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *networkingv1.NetworkPolicy but got %T", resource)
+	}
+
+	rows := []sql.Row{}
+	add := func(direction networkingv1.PolicyType, rule int, peers []networkingv1.NetworkPolicyPeer, ports []networkingv1.NetworkPolicyPort) {
+		if len(peers) == 0 {
+			peers = []networkingv1.NetworkPolicyPeer{{}}
+		}
+		if len(ports) == 0 {
+			ports = []networkingv1.NetworkPolicyPort{{}}
+		}
+		for _, peer := range peers {
+			for _, port := range ports {
+				rows = append(rows, append(sql.NewRow(string(policy.UID), policy.Name, policy.Namespace,
+					strings.ToLower(string(direction)),
+					int32(rule)),
+					networkPolicyPeerColumns(&peer, &port)...))
+			}
+		}
+	}
+
+	for i, rule := range policy.Spec.Ingress {
+		add(networkingv1.PolicyTypeIngress, i, rule.From, rule.Ports)
+	}
+	for i, rule := range policy.Spec.Egress {
+		add(networkingv1.PolicyTypeEgress, i, rule.To, rule.Ports)
+	}
+	return rows, nil
+}
+
+func networkPolicyPeerColumns(peer *networkingv1.NetworkPolicyPeer, port *networkingv1.NetworkPolicyPort) sql.Row {
+	var podSelector, namespaceSelector, ipBlock, ipBlockExcept interface{}
+	if peer.PodSelector != nil {
+		podSelector = networkPolicySelector(peer.PodSelector)
+	}
+	if peer.NamespaceSelector != nil {
+		namespaceSelector = networkPolicySelector(peer.NamespaceSelector)
+	}
+	if peer.IPBlock != nil {
+		ipBlock = peer.IPBlock.CIDR
+		ipBlockExcept = strings.Join(peer.IPBlock.Except, ",")
+	}
+
+	var protocol, portValue interface{}
+	if port.Protocol != nil {
+		protocol = string(*port.Protocol)
+	} else if port.Port != nil {
+		protocol = "TCP"
+	}
+	if port.Port != nil {
+		portValue = port.Port.String()
+	}
+
+	return sql.NewRow(podSelector, namespaceSelector, ipBlock, ipBlockExcept,
+		protocol,
+		portValue,
+		nullableInt32(port.EndPort))
+}
+
+// networkPolicySelector formats a selector of a network policy. An empty
+// selector selects every pod or namespace and is formatted as an empty
+// string, the label selector matching everything, where
+// metav1.FormatLabelSelector would report <none>.
+func networkPolicySelector(selector *metav1.LabelSelector) string {
+	if len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0 {
+		return ""
+	}
+	return metav1.FormatLabelSelector(selector)
+}
@@ -0,0 +1,193 @@
+package tables
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     NodeTableName,
+		Resource: NodeResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: NodeTableName},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: NodeTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: true, Source: NodeTableName},
+			{Name: "free_memory", Type: sql.Int64, Nullable: false, Source: NodeTableName},
+			{Name: "free_cpu", Type: sql.Int64, Nullable: false, Source: NodeTableName},
+			{Name: "free_disk", Type: sql.Int64, Nullable: false, Source: NodeTableName},
+			{Name: "capacity_memory", Type: sql.Int64, Nullable: false, Source: NodeTableName},
+			{Name: "capacity_cpu", Type: sql.Int64, Nullable: false, Source: NodeTableName},
+			{Name: "capacity_disk", Type: sql.Int64, Nullable: false, Source: NodeTableName},
+			{Name: "roles", Type: sql.Text, Nullable: false, Source: NodeTableName},
+			{Name: "unschedulable", Type: sql.Boolean, Nullable: false, Source: NodeTableName},
+			{Name: "kubelet_version", Type: sql.Text, Nullable: false, Source: NodeTableName},
+			{Name: "kernel_version", Type: sql.Text, Nullable: false, Source: NodeTableName},
+			{Name: "os_image", Type: sql.Text, Nullable: false, Source: NodeTableName},
+			{Name: "operating_system", Type: sql.Text, Nullable: false, Source: NodeTableName},
+			{Name: "architecture", Type: sql.Text, Nullable: false, Source: NodeTableName},
+			{Name: "container_runtime", Type: sql.Text, Nullable: false, Source: NodeTableName},
+			{Name: "pod_cidr", Type: sql.Text, Nullable: false, Source: NodeTableName},
+			{Name: "pod_cidrs", Type: sql.Text, Nullable: false, Source: NodeTableName},
+			{Name: "provider_id", Type: sql.Text, Nullable: false, Source: NodeTableName},
+			{Name: "zone", Type: sql.Text, Nullable: false, Source: NodeTableName},
+			{Name: "region", Type: sql.Text, Nullable: false, Source: NodeTableName},
+			{Name: "labels", Type: sql.JSON, Nullable: false, Source: NodeTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: NodeTableName},
+		},
+		Rows: nodeRows,
+	})
+
+	Register(TableProvider{
+		Name:     NodeConditionTableName,
+		Resource: NodeResource,
+		Schema: sql.Schema{
+			{Name: "node_uid", Type: sql.Text, Nullable: false, Source: NodeConditionTableName},
+			{Name: "node", Type: sql.Text, Nullable: false, Source: NodeConditionTableName},
+			{Name: "type", Type: sql.Text, Nullable: false, Source: NodeConditionTableName},
+			{Name: "status", Type: sql.Text, Nullable: false, Source: NodeConditionTableName},
+			{Name: "reason", Type: sql.Text, Nullable: false, Source: NodeConditionTableName},
+			{Name: "message", Type: sql.Text, Nullable: false, Source: NodeConditionTableName},
+			{Name: "last_heartbeat_time", Type: sql.Datetime, Nullable: true, Source: NodeConditionTableName},
+			{Name: "last_transition_time", Type: sql.Datetime, Nullable: true, Source: NodeConditionTableName},
+		},
+		Rows: nodeConditionRows,
+	})
+
+	Register(TableProvider{
+		Name:     NodeTaintTableName,
+		Resource: NodeResource,
+		Schema: sql.Schema{
+			{Name: "node_uid", Type: sql.Text, Nullable: false, Source: NodeTaintTableName},
+			{Name: "node", Type: sql.Text, Nullable: false, Source: NodeTaintTableName},
+			{Name: "taint_key", Type: sql.Text, Nullable: false, Source: NodeTaintTableName},
+			{Name: "value", Type: sql.Text, Nullable: false, Source: NodeTaintTableName},
+			{Name: "effect", Type: sql.Text, Nullable: false, Source: NodeTaintTableName},
+			{Name: "time_added", Type: sql.Datetime, Nullable: true, Source: NodeTaintTableName},
+		},
+		Rows: nodeTaintRows,
+	})
+
+	Register(TableProvider{
+		Name:     NodeAddressTableName,
+		Resource: NodeResource,
+		Schema: sql.Schema{
+			{Name: "node_uid", Type: sql.Text, Nullable: false, Source: NodeAddressTableName},
+			{Name: "node", Type: sql.Text, Nullable: false, Source: NodeAddressTableName},
+			{Name: "type", Type: sql.Text, Nullable: false, Source: NodeAddressTableName},
+			{Name: "address", Type: sql.Text, Nullable: false, Source: NodeAddressTableName},
+		},
+		Rows: nodeAddressRows,
+	})
+}
+
+func nodeRows(resource interface{}) ([]sql.Row, error) {
+	node, ok := resource.(*v1.Node)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *v1.Node but got %T", resource)
+	}
+	return []sql.Row{nodeRow(node)}, nil
+}
+
+func nodeRow(node *v1.Node) sql.Row {
+	// nodes are cluster-scoped, namespace is kept NULL for compatibility
+	return sql.NewRow(string(node.UID), node.Name, nil, node.Status.Allocatable.Memory().Value(), node.Status.Allocatable.Cpu().MilliValue(),
+		node.Status.Allocatable.StorageEphemeral().Value(), node.Status.Capacity.Memory().Value(), node.Status.Capacity.Cpu().MilliValue(), node.Status.Capacity.StorageEphemeral().Value(),
+		nodeRoles(node.Labels),
+		boolValue(node.Spec.Unschedulable),
+		node.Status.NodeInfo.KubeletVersion,
+		node.Status.NodeInfo.KernelVersion,
+		node.Status.NodeInfo.OSImage,
+		node.Status.NodeInfo.OperatingSystem,
+		node.Status.NodeInfo.Architecture,
+		node.Status.NodeInfo.ContainerRuntimeVersion,
+		node.Spec.PodCIDR,
+		strings.Join(node.Spec.PodCIDRs, ","),
+		node.Spec.ProviderID,
+		topologyLabel(node.Labels, v1.LabelTopologyZone, v1.LabelFailureDomainBetaZone),
+		topologyLabel(node.Labels, v1.LabelTopologyRegion, v1.LabelFailureDomainBetaRegion),
+		mapJSON(node.Labels),
+		node.CreationTimestamp.Time)
+}
+
+const (
+	nodeRolePrefix = "node-role.kubernetes.io/"
+	nodeRoleLabel  = "kubernetes.io/role"
+)
+
+// nodeRoles lists the roles of a node the way kubectl get nodes does.
+func nodeRoles(labels map[string]string) string {
+	roles := sets.NewString()
+	for key, value := range labels {
+		switch {
+		case strings.HasPrefix(key, nodeRolePrefix):
+			if role := strings.TrimPrefix(key, nodeRolePrefix); role != "" {
+				roles.Insert(role)
+			}
+		case key == nodeRoleLabel && value != "":
+			roles.Insert(value)
+		}
+	}
+	return strings.Join(roles.List(), ",")
+}
+
+// topologyLabel returns the value of the first well-known topology label set.
+func topologyLabel(labels map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := labels[key]; ok {
+			return value
+		}
+	}
+	return ""
+}
+
+func nodeConditionRows(resource interface{}) ([]sql.Row, error) {
+	node, ok := resource.(*v1.Node)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *v1.Node but got %T", resource)
+	}
+
+	rows := make([]sql.Row, 0, len(node.Status.Conditions))
+	for _, condition := range node.Status.Conditions {
+		rows = append(rows, sql.NewRow(string(node.UID), node.Name,
+			string(condition.Type),
+			string(condition.Status),
+			condition.Reason,
+			condition.Message,
+			nullableTime(condition.LastHeartbeatTime.Time),
+			nullableTime(condition.LastTransitionTime.Time)))
+	}
+	return rows, nil
+}
+
+func nodeTaintRows(resource interface{}) ([]sql.Row, error) {
+	node, ok := resource.(*v1.Node)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *v1.Node but got %T", resource)
+	}
+
+	rows := make([]sql.Row, 0, len(node.Spec.Taints))
+	for _, taint := range node.Spec.Taints {
+		rows = append(rows, sql.NewRow(string(node.UID), node.Name,
+			taint.Key, taint.Value, string(taint.Effect),
+			nullableMetaTime(taint.TimeAdded)))
+	}
+	return rows, nil
+}
+
+func nodeAddressRows(resource interface{}) ([]sql.Row, error) {
+	node, ok := resource.(*v1.Node)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *v1.Node but got %T", resource)
+	}
+
+	rows := make([]sql.Row, 0, len(node.Status.Addresses))
+	for _, address := range node.Status.Addresses {
+		rows = append(rows, sql.NewRow(string(node.UID), node.Name, string(address.Type), address.Address))
+	}
+	return rows, nil
+}
@@ -0,0 +1,176 @@
+package tables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     NodeAffinityTableName,
+		Resource: PodResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: NodeAffinityTableName},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: NodeAffinityTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: NodeAffinityTableName},
+			{Name: "kind", Type: sql.Text, Nullable: false, Source: NodeAffinityTableName},
+			{Name: "polarity", Type: sql.Text, Nullable: false, Source: NodeAffinityTableName},
+			{Name: "weight", Type: sql.Int32, Nullable: true, Source: NodeAffinityTableName},
+			{Name: "topology_key", Type: sql.Text, Nullable: false, Source: NodeAffinityTableName},
+			{Name: "affinity", Type: sql.Text, Nullable: false, Source: NodeAffinityTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: NodeAffinityTableName},
+		},
+		Rows:    nodeAffinityRows,
+		Lookups: []Resource{NodeResource},
+	})
+}
+
+// nodeAffinityTerm is a node selector term flattened with the kind and
+// weight it was declared with. Required terms have no weight.
+type nodeAffinityTerm struct {
+	v1.NodeSelectorTerm
+	kind   string
+	weight *int32
+}
+
+// nodeAffinityRows emits a row per node matched by a required or preferred
+// node affinity term. Node affinity has no anti-affinity counterpart and
+// nodes are the topology domain, so polarity and topology_key are constant;
+// they keep the schema aligned with the affinity table.
+func nodeAffinityRows(resource interface{}) ([]sql.Row, error) {
+	pod, ok := resource.(*v1.Pod)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *v1.Pod but got %T", resource)
+	}
+
+	rows := []sql.Row{}
+	for _, term := range nodeAffinityTerms(pod) {
+		selectedNodes, err := lookupNodes(&term.NodeSelectorTerm)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, affinityNode := range selectedNodes {
+			rows = append(rows, affinityNodeRow(pod, affinityNode, &term))
+		}
+	}
+
+	return rows, nil
+}
+
+func nodeAffinityTerms(pod *v1.Pod) []nodeAffinityTerm {
+	terms := []nodeAffinityTerm{}
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.NodeAffinity == nil {
+		return terms
+	}
+
+	affinity := pod.Spec.Affinity.NodeAffinity
+	if required := affinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil {
+		for _, term := range required.NodeSelectorTerms {
+			terms = append(terms, nodeAffinityTerm{NodeSelectorTerm: term, kind: requiredTerm})
+		}
+	}
+	preferred := affinity.PreferredDuringSchedulingIgnoredDuringExecution
+	for i := range preferred {
+		terms = append(terms, nodeAffinityTerm{
+			NodeSelectorTerm: preferred[i].Preference,
+			kind:             preferredTerm,
+			weight:           &preferred[i].Weight,
+		})
+	}
+
+	return terms
+}
+
+// nodeSelectorTermSelectors converts the match expressions and match fields
+// of a node selector term, which are ANDed, into label and field selectors.
+func nodeSelectorTermSelectors(term *v1.NodeSelectorTerm) (labels.Selector, fields.Selector, error) {
+	labelSelector := labels.NewSelector()
+	for _, expression := range term.MatchExpressions {
+		op, err := nodeSelectorOperator(expression.Operator)
+		if err != nil {
+			return nil, nil, err
+		}
+		r, err := labels.NewRequirement(expression.Key, op, expression.Values)
+		if err != nil {
+			return nil, nil, err
+		}
+		labelSelector = labelSelector.Add(*r)
+	}
+
+	fieldSelectors := []fields.Selector{}
+	for _, field := range term.MatchFields {
+		if len(field.Values) != 1 {
+			return nil, nil, fmt.Errorf("node selector field %q expects exactly one value, got %d", field.Key, len(field.Values))
+		}
+		switch field.Operator {
+		case v1.NodeSelectorOpIn:
+			fieldSelectors = append(fieldSelectors, fields.OneTermEqualSelector(field.Key, field.Values[0]))
+		case v1.NodeSelectorOpNotIn:
+			fieldSelectors = append(fieldSelectors, fields.OneTermNotEqualSelector(field.Key, field.Values[0]))
+		default:
+			return nil, nil, fmt.Errorf("unsupported operator %q for node selector field %q", field.Operator, field.Key)
+		}
+	}
+
+	return labelSelector, fields.AndSelectors(fieldSelectors...), nil
+}
+
+func nodeSelectorOperator(operator v1.NodeSelectorOperator) (selection.Operator, error) {
+	switch operator {
+	case v1.NodeSelectorOpIn:
+		return selection.In, nil
+	case v1.NodeSelectorOpNotIn:
+		return selection.NotIn, nil
+	case v1.NodeSelectorOpExists:
+		return selection.Exists, nil
+	case v1.NodeSelectorOpDoesNotExist:
+		return selection.DoesNotExist, nil
+	case v1.NodeSelectorOpGt:
+		return selection.GreaterThan, nil
+	case v1.NodeSelectorOpLt:
+		return selection.LessThan, nil
+	default:
+		return "", fmt.Errorf("cannot convert NodeSelectorRequirment operator into a proper Selector operator")
+	}
+}
+
+// lookupNodes matches a node selector term against the node cache.
+func lookupNodes(term *v1.NodeSelectorTerm) ([]*v1.Node, error) {
+	labelSelector, fieldSelector, err := nodeSelectorTermSelectors(term)
+	if err != nil {
+		return nil, err
+	}
+
+	store, ok := LookupCache(NodeResource)
+	if !ok {
+		return nil, nil
+	}
+
+	selected := []*v1.Node{}
+	for _, obj := range store.List() {
+		node, ok := obj.(*v1.Node)
+		if !ok {
+			continue
+		}
+		if labelSelector.Matches(labels.Set(node.Labels)) && fieldSelector.Matches(fields.Set{"metadata.name": node.Name}) {
+			selected = append(selected, node)
+		}
+	}
+	return selected, nil
+}
+
+func affinityNodeRow(pod *v1.Pod, affinityNode *v1.Node, term *nodeAffinityTerm) sql.Row {
+	return sql.NewRow(string(pod.UID), pod.Name, pod.Namespace,
+		term.kind,
+		affinityPolarity,
+		nullableInt32(term.weight),
+		v1.LabelHostname,
+		affinityNode.Name,
+		pod.CreationTimestamp.Time)
+}
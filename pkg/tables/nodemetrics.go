@@ -0,0 +1,41 @@
+package tables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     NodeMetricsTableName,
+		Resource: NodeMetricsResource,
+		Schema: sql.Schema{
+			{Name: "name", Type: sql.Text, Nullable: false, Source: NodeMetricsTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: NodeMetricsTableName},
+			{Name: "window", Type: sql.Int64, Nullable: false, Source: NodeMetricsTableName},
+			{Name: "usage_memory", Type: sql.Int64, Nullable: false, Source: NodeMetricsTableName},
+			{Name: "usage_cpu", Type: sql.Int64, Nullable: false, Source: NodeMetricsTableName},
+			{Name: "usage_disk", Type: sql.Int64, Nullable: false, Source: NodeMetricsTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: NodeMetricsTableName},
+		},
+		Rows: nodeMetricsRows,
+	})
+}
+
+func nodeMetricsRows(resource interface{}) ([]sql.Row, error) {
+	metrics, ok := resource.(*v1beta1.NodeMetrics)
+	if !ok {
+		return nil, fmt.Errorf("resource is not of type *v1beta1.NodeMetrics")
+	}
+	return []sql.Row{nodeMetricsRow(metrics)}, nil
+}
+
+func nodeMetricsRow(metrics *v1beta1.NodeMetrics) sql.Row {
+	return sql.NewRow(metrics.Name, metrics.Namespace, metrics.Window.Milliseconds(),
+		metrics.Usage.Memory().Value(),
+		metrics.Usage.Cpu().MilliValue(),
+		metrics.Usage.StorageEphemeral().Value(),
+		metrics.CreationTimestamp.Time)
+}
@@ -0,0 +1,114 @@
+package tables
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8srt "k8s.io/apimachinery/pkg/runtime"
+)
+
+// ownerResources maps the kinds followed when resolving the top owner of an
+// object to the resource caching them.
+var ownerResources = map[string]Resource{
+	"ReplicaSet":  ReplicaSetResource,
+	"Deployment":  DeploymentResource,
+	"StatefulSet": StatefulSetResource,
+	"DaemonSet":   DaemonSetResource,
+	"Job":         JobResource,
+	"CronJob":     CronJobResource,
+}
+
+func init() {
+	Register(TableProvider{
+		Name:     OwnerRefTableName,
+		Resource: AnyResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: OwnerRefTableName},
+			{Name: "kind", Type: sql.Text, Nullable: false, Source: OwnerRefTableName},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: OwnerRefTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: OwnerRefTableName},
+			{Name: "owner_uid", Type: sql.Text, Nullable: false, Source: OwnerRefTableName},
+			{Name: "owner_kind", Type: sql.Text, Nullable: false, Source: OwnerRefTableName},
+			{Name: "owner_name", Type: sql.Text, Nullable: false, Source: OwnerRefTableName},
+			{Name: "controller", Type: sql.Boolean, Nullable: false, Source: OwnerRefTableName},
+		},
+		Rows: ownerRefRows,
+	})
+}
+
+func ownerRefRows(resource interface{}) ([]sql.Row, error) {
+	obj, err := meta.Accessor(resource)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected type for resource, expected metav1.Object but got %T", resource)
+	}
+
+	kind := objectKind(resource)
+	rows := make([]sql.Row, 0, len(obj.GetOwnerReferences()))
+	for _, owner := range obj.GetOwnerReferences() {
+		rows = append(rows, sql.NewRow(string(obj.GetUID()), kind, obj.GetName(), obj.GetNamespace(),
+			string(owner.UID), owner.Kind, owner.Name, boolValue(owner.Controller != nil && *owner.Controller)))
+	}
+	return rows, nil
+}
+
+// objectKind returns the kind of resource. Objects served by typed informers
+// carry no TypeMeta, so their kind falls back to the name of their Go type.
+func objectKind(resource interface{}) string {
+	if obj, ok := resource.(k8srt.Object); ok {
+		if kind := obj.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+			return kind
+		}
+	}
+
+	t := reflect.TypeOf(resource)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// topOwner follows the controller references of obj through the informer
+// caches and returns the kind and name of the outermost controller. Objects
+// without a controller are their own top owner. The walk stops at the first
+// controller that is not cached.
+func topOwner(kind string, obj metav1.Object) (string, string) {
+	visited := map[string]bool{}
+	for {
+		controller := metav1.GetControllerOfNoCopy(obj)
+		if controller == nil || visited[string(controller.UID)] {
+			return kind, obj.GetName()
+		}
+		visited[string(controller.UID)] = true
+		kind = controller.Kind
+
+		owner, ok := lookupOwner(obj.GetNamespace(), controller)
+		if !ok {
+			return controller.Kind, controller.Name
+		}
+		obj = owner
+	}
+}
+
+func lookupOwner(namespace string, ref *metav1.OwnerReference) (metav1.Object, bool) {
+	resource, ok := ownerResources[ref.Kind]
+	if !ok {
+		return nil, false
+	}
+	store, ok := LookupCache(resource)
+	if !ok {
+		return nil, false
+	}
+
+	item, exists, err := store.GetByKey(namespace + "/" + ref.Name)
+	if err != nil || !exists {
+		return nil, false
+	}
+	owner, err := meta.Accessor(item)
+	if err != nil || owner.GetUID() != ref.UID {
+		return nil, false
+	}
+	return owner, true
+}
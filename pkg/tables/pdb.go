@@ -0,0 +1,58 @@
+package tables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     PDBTableName,
+		Resource: PDBResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: PDBTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: PDBTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: PDBTableName},
+			{Name: "min_available", Type: sql.Text, Nullable: true, Source: PDBTableName},
+			{Name: "max_unavailable", Type: sql.Text, Nullable: true, Source: PDBTableName},
+			{Name: "current_healthy", Type: sql.Int32, Nullable: false, Source: PDBTableName},
+			{Name: "desired_healthy", Type: sql.Int32, Nullable: false, Source: PDBTableName},
+			{Name: "expected_pods", Type: sql.Int32, Nullable: false, Source: PDBTableName},
+			{Name: "disruptions_allowed", Type: sql.Int32, Nullable: false, Source: PDBTableName},
+			{Name: "selector", Type: sql.Text, Nullable: false, Source: PDBTableName},
+			{Name: "labels", Type: sql.JSON, Nullable: false, Source: PDBTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: PDBTableName},
+		},
+		Rows: pdbRows,
+	})
+}
+
+func pdbRows(resource interface{}) ([]sql.Row, error) {
+	pdb, ok := resource.(*policyv1.PodDisruptionBudget)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *policyv1.PodDisruptionBudget but got %T", resource)
+	}
+
+	return []sql.Row{sql.NewRow(string(pdb.UID), pdb.Name, pdb.Namespace,
+		intOrString(pdb.Spec.MinAvailable),
+		intOrString(pdb.Spec.MaxUnavailable),
+		pdb.Status.CurrentHealthy,
+		pdb.Status.DesiredHealthy,
+		pdb.Status.ExpectedPods,
+		pdb.Status.DisruptionsAllowed,
+		metav1.FormatLabelSelector(pdb.Spec.Selector),
+		mapJSON(pdb.Labels),
+		pdb.CreationTimestamp.Time)}, nil
+}
+
+// intOrString renders a count or a percentage as written in the spec.
+func intOrString(value *intstr.IntOrString) interface{} {
+	if value == nil {
+		return nil
+	}
+	return value.String()
+}
@@ -0,0 +1,97 @@
+package tables
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	v1 "k8s.io/api/core/v1"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     PodTableName,
+		Resource: PodResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: PodTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: PodTableName, PrimaryKey: true},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: PodTableName},
+			{Name: "application", Type: sql.Text, Nullable: false, Source: PodTableName},
+			{Name: "deployment", Type: sql.Text, Nullable: false, Source: PodTableName},
+			{Name: "owner_uid", Type: sql.Text, Nullable: false, Source: PodTableName},
+			{Name: "top_owner_kind", Type: sql.Text, Nullable: false, Source: PodTableName},
+			{Name: "top_owner_name", Type: sql.Text, Nullable: false, Source: PodTableName},
+			{Name: "node", Type: sql.Text, Nullable: false, Source: PodTableName},
+			{Name: "ip", Type: sql.Text, Nullable: false, Source: PodTableName},
+			{Name: "ips", Type: sql.Text, Nullable: false, Source: PodTableName},
+			{Name: "phase", Type: sql.Text, Nullable: false, Source: PodTableName},
+			{Name: "qos_class", Type: sql.Text, Nullable: false, Source: PodTableName},
+			{Name: "priority", Type: sql.Int32, Nullable: true, Source: PodTableName},
+			{Name: "priority_class", Type: sql.Text, Nullable: false, Source: PodTableName},
+			{Name: "service_account", Type: sql.Text, Nullable: false, Source: PodTableName},
+			{Name: "host_network", Type: sql.Boolean, Nullable: false, Source: PodTableName},
+			{Name: "restart_policy", Type: sql.Text, Nullable: false, Source: PodTableName},
+			{Name: "scheduler", Type: sql.Text, Nullable: false, Source: PodTableName},
+			{Name: "nominated_node", Type: sql.Text, Nullable: false, Source: PodTableName},
+			{Name: "started_at", Type: sql.Datetime, Nullable: true, Source: PodTableName},
+			{Name: "deleted_at", Type: sql.Datetime, Nullable: true, Source: PodTableName},
+			{Name: "labels", Type: sql.JSON, Nullable: false, Source: PodTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: PodTableName},
+		},
+		Rows:    podRows,
+		Lookups: []Resource{ReplicaSetResource, DeploymentResource, StatefulSetResource, DaemonSetResource, JobResource, CronJobResource},
+	})
+}
+
+func podRows(resource interface{}) ([]sql.Row, error) {
+	pod, ok := resource.(*v1.Pod)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *v1.Pod but got %T", resource)
+	}
+	return []sql.Row{podRow(pod)}, nil
+}
+
+// podRow resolves the deployment of a pod from its top owner, pods not rolled
+// out by a Deployment have an empty deployment.
+func podRow(pod *v1.Pod) sql.Row {
+	topOwnerKind, topOwnerName := topOwner("Pod", pod)
+	deploymentName := ""
+	if topOwnerKind == "Deployment" {
+		deploymentName = topOwnerName
+	}
+
+	labels := pod.GetLabels()
+	app := labels["app"]
+
+	var priority interface{}
+	if pod.Spec.Priority != nil {
+		priority = *pod.Spec.Priority
+	}
+
+	return sql.NewRow(string(pod.UID), pod.Name, pod.Namespace, app, deploymentName, controllerUID(&pod.ObjectMeta),
+		topOwnerKind, topOwnerName, pod.Spec.NodeName,
+		pod.Status.PodIP,
+		podIPs(pod.Status.PodIPs),
+		string(pod.Status.Phase),
+		string(pod.Status.QOSClass),
+		priority,
+		pod.Spec.PriorityClassName,
+		pod.Spec.ServiceAccountName,
+		boolValue(pod.Spec.HostNetwork),
+		string(pod.Spec.RestartPolicy),
+		pod.Spec.SchedulerName,
+		pod.Status.NominatedNodeName,
+		nullableMetaTime(pod.Status.StartTime),
+		nullableMetaTime(pod.DeletionTimestamp),
+		mapJSON(pod.Labels),
+		pod.CreationTimestamp.Time)
+}
+
+// podIPs joins the addresses of a dual-stack pod, primary address first.
+func podIPs(ips []v1.PodIP) string {
+	joined := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		joined = append(joined, ip.IP)
+	}
+	return strings.Join(joined, ",")
+}
@@ -0,0 +1,63 @@
+package tables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	v1 "k8s.io/api/core/v1"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     PodConditionTableName,
+		Resource: PodResource,
+		Schema: sql.Schema{
+			{Name: "pod_uid", Type: sql.Text, Nullable: false, Source: PodConditionTableName},
+			{Name: "pod", Type: sql.Text, Nullable: false, Source: PodConditionTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: PodConditionTableName},
+			{Name: "type", Type: sql.Text, Nullable: false, Source: PodConditionTableName},
+			{Name: "readiness_gate", Type: sql.Boolean, Nullable: false, Source: PodConditionTableName},
+			{Name: "status", Type: sql.Text, Nullable: true, Source: PodConditionTableName},
+			{Name: "reason", Type: sql.Text, Nullable: false, Source: PodConditionTableName},
+			{Name: "message", Type: sql.Text, Nullable: false, Source: PodConditionTableName},
+			{Name: "last_probe_time", Type: sql.Datetime, Nullable: true, Source: PodConditionTableName},
+			{Name: "last_transition_time", Type: sql.Datetime, Nullable: true, Source: PodConditionTableName},
+		},
+		Rows: podConditionRows,
+	})
+}
+
+// podConditionRows emits a row per pod condition. Readiness gates the pod
+// status does not report yet are emitted with a NULL status.
+func podConditionRows(resource interface{}) ([]sql.Row, error) {
+	pod, ok := resource.(*v1.Pod)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *v1.Pod but got %T", resource)
+	}
+
+	gates := map[v1.PodConditionType]bool{}
+	for _, gate := range pod.Spec.ReadinessGates {
+		gates[gate.ConditionType] = true
+	}
+
+	rows := make([]sql.Row, 0, len(pod.Status.Conditions)+len(gates))
+	for _, condition := range pod.Status.Conditions {
+		rows = append(rows, sql.NewRow(string(pod.UID), pod.Name, pod.Namespace,
+			string(condition.Type),
+			boolValue(gates[condition.Type]),
+			string(condition.Status),
+			condition.Reason,
+			condition.Message,
+			nullableTime(condition.LastProbeTime.Time),
+			nullableTime(condition.LastTransitionTime.Time)))
+		delete(gates, condition.Type)
+	}
+
+	for _, gate := range pod.Spec.ReadinessGates {
+		if gates[gate.ConditionType] {
+			rows = append(rows, sql.NewRow(string(pod.UID), pod.Name, pod.Namespace,
+				string(gate.ConditionType), boolValue(true), nil, "", "", nil, nil))
+		}
+	}
+	return rows, nil
+}
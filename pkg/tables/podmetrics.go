@@ -0,0 +1,47 @@
+package tables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     PodMetricsTableName,
+		Resource: PodMetricsResource,
+		Schema: sql.Schema{
+			{Name: "pod", Type: sql.Text, Nullable: false, Source: PodMetricsTableName},
+			{Name: "container", Type: sql.Text, Nullable: false, Source: PodMetricsTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: PodMetricsTableName},
+			{Name: "window", Type: sql.Int64, Nullable: false, Source: PodMetricsTableName},
+			{Name: "usage_memory", Type: sql.Int64, Nullable: false, Source: PodMetricsTableName},
+			{Name: "usage_cpu", Type: sql.Int64, Nullable: false, Source: PodMetricsTableName},
+			{Name: "usage_disk", Type: sql.Int64, Nullable: false, Source: PodMetricsTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: PodMetricsTableName},
+		},
+		Rows: podMetricsRows,
+	})
+}
+
+func podMetricsRows(resource interface{}) ([]sql.Row, error) {
+	metrics, ok := resource.(*v1beta1.PodMetrics)
+	if !ok {
+		return nil, fmt.Errorf("resource is not of type *v1beta1.PodMetrics")
+	}
+
+	rows := make([]sql.Row, 0, len(metrics.Containers))
+	for _, container := range metrics.Containers {
+		rows = append(rows, podMetricsRow(metrics, &container))
+	}
+	return rows, nil
+}
+
+func podMetricsRow(metrics *v1beta1.PodMetrics, container_metrics *v1beta1.ContainerMetrics) sql.Row {
+	return sql.NewRow(metrics.Name, container_metrics.Name, metrics.Namespace, metrics.Window.Milliseconds(),
+		container_metrics.Usage.Memory().Value(),
+		container_metrics.Usage.Cpu().MilliValue(),
+		container_metrics.Usage.StorageEphemeral().Value(),
+		metrics.CreationTimestamp.Time)
+}
@@ -0,0 +1,89 @@
+package tables
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	v1 "k8s.io/api/core/v1"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     PodTolerationTableName,
+		Resource: PodResource,
+		Schema: sql.Schema{
+			{Name: "pod_uid", Type: sql.Text, Nullable: false, Source: PodTolerationTableName},
+			{Name: "pod", Type: sql.Text, Nullable: false, Source: PodTolerationTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: PodTolerationTableName},
+			{Name: "toleration_key", Type: sql.Text, Nullable: false, Source: PodTolerationTableName},
+			{Name: "operator", Type: sql.Text, Nullable: false, Source: PodTolerationTableName},
+			{Name: "value", Type: sql.Text, Nullable: false, Source: PodTolerationTableName},
+			{Name: "effect", Type: sql.Text, Nullable: false, Source: PodTolerationTableName},
+			{Name: "toleration_seconds", Type: sql.Int64, Nullable: true, Source: PodTolerationTableName},
+		},
+		Rows: podTolerationRows,
+	})
+
+	Register(TableProvider{
+		Name:     PodNodeSelectorTableName,
+		Resource: PodResource,
+		Schema: sql.Schema{
+			{Name: "pod_uid", Type: sql.Text, Nullable: false, Source: PodNodeSelectorTableName},
+			{Name: "pod", Type: sql.Text, Nullable: false, Source: PodNodeSelectorTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: PodNodeSelectorTableName},
+			{Name: "selector_key", Type: sql.Text, Nullable: false, Source: PodNodeSelectorTableName},
+			{Name: "value", Type: sql.Text, Nullable: false, Source: PodNodeSelectorTableName},
+		},
+		Rows: podNodeSelectorRows,
+	})
+}
+
+// podTolerationRows emits a row per toleration. An empty operator defaults
+// to Equal, and an empty effect tolerates every effect.
+func podTolerationRows(resource interface{}) ([]sql.Row, error) {
+	pod, ok := resource.(*v1.Pod)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *v1.Pod but got %T", resource)
+	}
+
+	rows := make([]sql.Row, 0, len(pod.Spec.Tolerations))
+	for _, toleration := range pod.Spec.Tolerations {
+		operator := toleration.Operator
+		if operator == "" {
+			operator = v1.TolerationOpEqual
+		}
+
+		var seconds interface{}
+		if toleration.TolerationSeconds != nil {
+			seconds = *toleration.TolerationSeconds
+		}
+
+		rows = append(rows, sql.NewRow(string(pod.UID), pod.Name, pod.Namespace,
+			toleration.Key,
+			string(operator),
+			toleration.Value,
+			string(toleration.Effect),
+			seconds))
+	}
+	return rows, nil
+}
+
+func podNodeSelectorRows(resource interface{}) ([]sql.Row, error) {
+	pod, ok := resource.(*v1.Pod)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *v1.Pod but got %T", resource)
+	}
+
+	keys := make([]string, 0, len(pod.Spec.NodeSelector))
+	for key := range pod.Spec.NodeSelector {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	rows := make([]sql.Row, 0, len(keys))
+	for _, key := range keys {
+		rows = append(rows, sql.NewRow(string(pod.UID), pod.Name, pod.Namespace, key, pod.Spec.NodeSelector[key]))
+	}
+	return rows, nil
+}
@@ -0,0 +1,107 @@
+package tables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	v1 "k8s.io/api/core/v1"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     PodVolumeTableName,
+		Resource: PodResource,
+		Schema: sql.Schema{
+			{Name: "pod_uid", Type: sql.Text, Nullable: false, Source: PodVolumeTableName},
+			{Name: "pod", Type: sql.Text, Nullable: false, Source: PodVolumeTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: PodVolumeTableName},
+			{Name: "volume", Type: sql.Text, Nullable: false, Source: PodVolumeTableName},
+			{Name: "type", Type: sql.Text, Nullable: false, Source: PodVolumeTableName},
+			{Name: "claim_name", Type: sql.Text, Nullable: false, Source: PodVolumeTableName},
+			{Name: "config_map", Type: sql.Text, Nullable: false, Source: PodVolumeTableName},
+			{Name: "secret", Type: sql.Text, Nullable: false, Source: PodVolumeTableName},
+			{Name: "size_limit", Type: sql.Int64, Nullable: true, Source: PodVolumeTableName},
+			{Name: "host_path", Type: sql.Text, Nullable: false, Source: PodVolumeTableName},
+			{Name: "container", Type: sql.Text, Nullable: true, Source: PodVolumeTableName},
+			{Name: "mount_path", Type: sql.Text, Nullable: true, Source: PodVolumeTableName},
+			{Name: "sub_path", Type: sql.Text, Nullable: true, Source: PodVolumeTableName},
+			{Name: "read_only", Type: sql.Boolean, Nullable: true, Source: PodVolumeTableName},
+		},
+		Rows: podVolumeRows,
+	})
+}
+
+// podVolumeRows emits a row per volume and container mounting it, init and
+// ephemeral containers included. Volumes no container mounts are emitted
+// once with NULL mount columns.
+func podVolumeRows(resource interface{}) ([]sql.Row, error) {
+	pod, ok := resource.(*v1.Pod)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *v1.Pod but got %T", resource)
+	}
+
+	type mount struct {
+		container string
+		v1.VolumeMount
+	}
+	mounts := map[string][]mount{}
+	addMounts := func(container string, volumeMounts []v1.VolumeMount) {
+		for _, volumeMount := range volumeMounts {
+			mounts[volumeMount.Name] = append(mounts[volumeMount.Name], mount{container, volumeMount})
+		}
+	}
+	for _, container := range pod.Spec.InitContainers {
+		addMounts(container.Name, container.VolumeMounts)
+	}
+	for _, container := range pod.Spec.Containers {
+		addMounts(container.Name, container.VolumeMounts)
+	}
+	for _, container := range pod.Spec.EphemeralContainers {
+		addMounts(container.Name, container.VolumeMounts)
+	}
+
+	rows := []sql.Row{}
+	for _, volume := range pod.Spec.Volumes {
+		row := podVolumeRow(pod, &volume)
+		if len(mounts[volume.Name]) == 0 {
+			rows = append(rows, append(row, nil, nil, nil, nil))
+			continue
+		}
+		for _, m := range mounts[volume.Name] {
+			rows = append(rows, append(row.Copy(), m.container, m.MountPath, m.SubPath, boolValue(m.ReadOnly)))
+		}
+	}
+	return rows, nil
+}
+
+func podVolumeRow(pod *v1.Pod, volume *v1.Volume) sql.Row {
+	var claimName, configMap, secret, hostPath string
+	var sizeLimit interface{}
+	switch {
+	case volume.PersistentVolumeClaim != nil:
+		claimName = volume.PersistentVolumeClaim.ClaimName
+	case volume.ConfigMap != nil:
+		configMap = volume.ConfigMap.Name
+	case volume.Secret != nil:
+		secret = volume.Secret.SecretName
+	case volume.HostPath != nil:
+		hostPath = volume.HostPath.Path
+	case volume.EmptyDir != nil:
+		if volume.EmptyDir.SizeLimit != nil {
+			sizeLimit = volume.EmptyDir.SizeLimit.Value()
+		}
+	case volume.Ephemeral != nil:
+		// generic ephemeral volumes are backed by a claim named after the
+		// pod and the volume
+		claimName = pod.Name + "-" + volume.Name
+	}
+
+	return sql.NewRow(string(pod.UID), pod.Name, pod.Namespace,
+		volume.Name,
+		volumeSourceType(volume.VolumeSource),
+		claimName,
+		configMap,
+		secret,
+		sizeLimit,
+		hostPath)
+}
@@ -0,0 +1,358 @@
+package tables
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/adalrsjr1/sqlcluster/internal/services"
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// TableProvider declares a table served by ClusterSQL. Tables projected from
+// a Resource set Resource and Rows; tables fed by other means set Start.
+type TableProvider struct {
+	// Name is the SQL name of the table.
+	Name string
+	// Schema lists the columns of the table. Columns without a Source are
+	// assigned to the table. Tables with a row per object mark its uid as
+	// primary key; tables flattening objects into several rows have none.
+	Schema sql.Schema
+	// Resource is the Kubernetes resource the rows are projected from, or
+	// AnyResource to project from every resource watched by the server.
+	Resource Resource
+	// Resources, when set instead of Resource, lists several resources the
+	// rows are projected from, such as the namespaced and cluster-wide
+	// kinds of an object. Objects and Retention do not apply to them.
+	Resources []Resource
+	// Rows projects one object of Resource into the rows it contributes to
+	// the table.
+	Rows func(obj interface{}) ([]sql.Row, error)
+	// Lookups lists the resources whose caches Rows reads through
+	// LookupCache. They are watched even if no table projects from them.
+	Lookups []Resource
+	// Retention, when set to a positive duration, keeps projecting objects
+	// for that long after they are deleted from the cluster. Tables setting
+	// Objects get it passed to Objects instead, which decides what to keep.
+	Retention time.Duration
+	// Retain, when set, selects the deleted objects kept for Retention.
+	// Every deleted object is kept otherwise.
+	Retain func(obj interface{}) bool
+	// Objects, when set, replaces the informer cache of Resource as the
+	// source of the objects projected by Rows. The source is subscribed to
+	// the events of Resource, so it can record what the cache does not keep
+	// for up to retention.
+	Objects func(retention time.Duration) ObjectSource
+	// Start creates and feeds the table itself until ctx is done. It is
+	// used by tables that are not projected from a Resource.
+	Start func(ctx *sql.Context, db *memory.Database)
+}
+
+// ObjectSource builds the objects of a table from the events of a resource.
+type ObjectSource interface {
+	cache.ResourceEventHandler
+	List() []interface{}
+}
+
+var (
+	registryMu       sync.RWMutex
+	providers        = map[string]TableProvider{}
+	resources        = map[Resource]func(factory informers.SharedInformerFactory) cache.SharedIndexInformer{}
+	dynamicResources = map[Resource][]schema.GroupVersionResource{}
+)
+
+// errNotServed reports a dynamic resource the API server does not serve,
+// typically because its CustomResourceDefinition is not installed.
+var errNotServed = errors.New("resource not served by the API server")
+
+// Register makes a table available to the server. It is meant to be called
+// from init functions and panics if the provider is invalid or its name is
+// already taken.
+func Register(provider TableProvider) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if provider.Name == "" {
+		panic("tables: Register provider without name")
+	}
+	if _, ok := providers[provider.Name]; ok {
+		panic(fmt.Sprintf("tables: Register called twice for table %s", provider.Name))
+	}
+	if provider.Resource != "" && len(provider.Resources) > 0 {
+		panic(fmt.Sprintf("tables: table %s sets both Resource and Resources", provider.Name))
+	}
+	if provider.Start == nil && ((provider.Resource == "" && len(provider.Resources) == 0) || provider.Rows == nil) {
+		panic(fmt.Sprintf("tables: table %s needs either Start or Resource and Rows", provider.Name))
+	}
+
+	schema := make(sql.Schema, len(provider.Schema))
+	for i, column := range provider.Schema {
+		c := *column
+		if c.Source == "" {
+			c.Source = provider.Name
+		}
+		schema[i] = &c
+	}
+	provider.Schema = schema
+
+	providers[provider.Name] = provider
+}
+
+// RegisterResource declares how to obtain the informer of resource from the
+// shared informer factory. It panics if resource is already registered.
+func RegisterResource(resource Resource, newInformer func(factory informers.SharedInformerFactory) cache.SharedIndexInformer) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := resources[resource]; ok {
+		panic(fmt.Sprintf("tables: RegisterResource called twice for resource %s", resource))
+	}
+	if _, ok := dynamicResources[resource]; ok {
+		panic(fmt.Sprintf("tables: RegisterResource called twice for resource %s", resource))
+	}
+	resources[resource] = newInformer
+}
+
+// RegisterDynamicResource declares a resource without typed client, such as
+// a custom resource, watched through the dynamic client. The first of
+// versions served by the API server is watched; tables projecting from the
+// resource are skipped when none is. It panics if resource is already
+// registered.
+func RegisterDynamicResource(resource Resource, versions ...schema.GroupVersionResource) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if len(versions) == 0 {
+		panic(fmt.Sprintf("tables: RegisterDynamicResource called without versions for resource %s", resource))
+	}
+	if _, ok := resources[resource]; ok {
+		panic(fmt.Sprintf("tables: RegisterDynamicResource called twice for resource %s", resource))
+	}
+	if _, ok := dynamicResources[resource]; ok {
+		panic(fmt.Sprintf("tables: RegisterDynamicResource called twice for resource %s", resource))
+	}
+	dynamicResources[resource] = versions
+}
+
+// servedVersion returns the first of versions the API server serves. A
+// version left empty stands for the version the API server prefers.
+func servedVersion(versions []schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+	for _, gvr := range versions {
+		if gvr.Version == "" {
+			gvr.Version = preferredVersion(gvr.Group)
+		}
+		list, err := services.Clientset.Discovery().ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+		if err != nil {
+			continue
+		}
+		for _, apiResource := range list.APIResources {
+			if apiResource.Name == gvr.Resource {
+				return gvr, nil
+			}
+		}
+	}
+	return schema.GroupVersionResource{}, fmt.Errorf("%s: %w", versions[0].GroupResource(), errNotServed)
+}
+
+func preferredVersion(group string) string {
+	groups, err := services.Clientset.Discovery().ServerGroups()
+	if err != nil {
+		return ""
+	}
+	for _, g := range groups.Groups {
+		if g.Name == group {
+			return g.PreferredVersion.Version
+		}
+	}
+	return ""
+}
+
+// Providers returns every registered table sorted by name.
+func Providers() []TableProvider {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	registered := make([]TableProvider, 0, len(providers))
+	for _, provider := range providers {
+		registered = append(registered, provider)
+	}
+	sort.Slice(registered, func(i, j int) bool {
+		return registered[i].Name < registered[j].Name
+	})
+	return registered
+}
+
+// Run creates the tables of providers in db and starts feeding them. A single
+// informer is started per resource, and every table projecting from it reads
+// the same informer cache.
+func Run(ctx context.Context, db *memory.Database, providers []TableProvider) {
+	defer runtime.HandleCrash()
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	factory := informers.NewSharedInformerFactory(services.Clientset, 0)
+	dynamicFactory := dynamicinformer.NewDynamicSharedInformerFactory(services.DynamicClient, 0)
+
+	watch := func(resource Resource) (cache.SharedIndexInformer, error) {
+		var informer cache.SharedIndexInformer
+		if newInformer, ok := resources[resource]; ok {
+			informer = newInformer(factory)
+		} else if versions, ok := dynamicResources[resource]; ok {
+			gvr, err := servedVersion(versions)
+			if err != nil {
+				return nil, err
+			}
+			informer = dynamicFactory.ForResource(gvr).Informer()
+		} else {
+			return nil, fmt.Errorf("no informer registered for resource %s", resource)
+		}
+		setCache(resource, informer.GetStore())
+		return informer, nil
+	}
+
+	skip := func(provider TableProvider, err error) {
+		if errors.Is(err, errNotServed) {
+			log.Infof("table %s skipped: %v", provider.Name, err)
+			return
+		}
+		runtime.HandleError(fmt.Errorf("table %s: %w", provider.Name, err))
+	}
+
+	anyResource := []TableProvider{}
+	for _, provider := range providers {
+		if provider.Start != nil {
+			log.Infof("starting table: %s", provider.Name)
+			go provider.Start(sql.NewContext(ctx), db)
+			continue
+		}
+
+		lookupErr := false
+		for _, resource := range provider.Lookups {
+			if _, err := watch(resource); err != nil {
+				skip(provider, err)
+				lookupErr = true
+			}
+		}
+		if lookupErr {
+			continue
+		}
+
+		if len(provider.Resources) > 0 {
+			stores := map[string]objectLister{}
+			for _, resource := range provider.Resources {
+				informer, err := watch(resource)
+				if err != nil {
+					skip(provider, err)
+					stores = nil
+					break
+				}
+				stores[string(resource)] = informer.GetStore()
+			}
+			if stores == nil {
+				continue
+			}
+			db.AddTable(provider.Name, newStoreTable(provider, stores))
+			log.Infof("table [%s] created from %v", provider.Name, provider.Resources)
+			continue
+		}
+
+		if provider.Resource == AnyResource {
+			anyResource = append(anyResource, provider)
+			continue
+		}
+
+		informer, err := watch(provider.Resource)
+		if err != nil {
+			skip(provider, err)
+			continue
+		}
+		stores := map[string]objectLister{string(provider.Resource): informer.GetStore()}
+
+		if provider.Objects != nil {
+			source := provider.Objects(provider.Retention)
+			if _, err := informer.AddEventHandler(source); err != nil {
+				runtime.HandleError(fmt.Errorf("table %s: cannot subscribe to %s: %w", provider.Name, provider.Resource, err))
+				continue
+			}
+			stores[string(provider.Resource)] = source
+		}
+
+		if provider.Objects == nil && provider.Retention > 0 {
+			deleted := newRetainedStore(provider.Retention, provider.Retain)
+			if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{DeleteFunc: deleted.Add}); err != nil {
+				runtime.HandleError(fmt.Errorf("table %s: cannot retain deleted %s: %w", provider.Name, provider.Resource, err))
+				continue
+			}
+			stores[string(provider.Resource)+"/deleted"] = deleted
+		}
+
+		db.AddTable(provider.Name, newStoreTable(provider, stores))
+		log.Infof("table [%s] created from %s", provider.Name, provider.Resource)
+	}
+
+	// tables projecting from any resource see every resource watched above
+	for _, provider := range anyResource {
+		stores := map[string]objectLister{}
+		for resource, store := range watchedCaches() {
+			stores[string(resource)] = store
+		}
+		db.AddTable(provider.Name, newStoreTable(provider, stores))
+		log.Infof("table [%s] created from every watched resource", provider.Name)
+	}
+
+	factory.Start(ctx.Done())
+	dynamicFactory.Start(ctx.Done())
+
+	go func() {
+		for informerType, synced := range factory.WaitForCacheSync(ctx.Done()) {
+			if !synced {
+				runtime.HandleError(fmt.Errorf("timed out waiting for %v caches to sync", informerType))
+			}
+		}
+		for gvr, synced := range dynamicFactory.WaitForCacheSync(ctx.Done()) {
+			if !synced {
+				runtime.HandleError(fmt.Errorf("timed out waiting for %v caches to sync", gvr))
+			}
+		}
+	}()
+}
+
+var (
+	cachesMu sync.RWMutex
+	caches   = map[Resource]cache.Store{}
+)
+
+func setCache(resource Resource, store cache.Store) {
+	cachesMu.Lock()
+	defer cachesMu.Unlock()
+	caches[resource] = store
+}
+
+// LookupCache returns the informer cache of resource, which is only available
+// if a running table projects from it or lists it in its Lookups.
+func LookupCache(resource Resource) (cache.Store, bool) {
+	cachesMu.RLock()
+	defer cachesMu.RUnlock()
+	store, ok := caches[resource]
+	return store, ok
+}
+
+func watchedCaches() map[Resource]cache.Store {
+	cachesMu.RLock()
+	defer cachesMu.RUnlock()
+	watched := make(map[Resource]cache.Store, len(caches))
+	for resource, store := range caches {
+		watched[resource] = store
+	}
+	return watched
+}
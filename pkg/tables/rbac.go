@@ -0,0 +1,267 @@
+package tables
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     ServiceAccountTableName,
+		Resource: ServiceAccountResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: ServiceAccountTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: ServiceAccountTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: ServiceAccountTableName},
+			{Name: "automount_token", Type: sql.Boolean, Nullable: true, Source: ServiceAccountTableName},
+			{Name: "image_pull_secrets", Type: sql.Text, Nullable: false, Source: ServiceAccountTableName},
+			{Name: "labels", Type: sql.JSON, Nullable: false, Source: ServiceAccountTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: ServiceAccountTableName},
+		},
+		Rows: serviceAccountRows,
+	})
+
+	Register(TableProvider{
+		Name:     RoleTableName,
+		Resource: RoleResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: RoleTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: RoleTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: RoleTableName},
+			{Name: "rules", Type: sql.Int32, Nullable: false, Source: RoleTableName},
+			{Name: "labels", Type: sql.JSON, Nullable: false, Source: RoleTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: RoleTableName},
+		},
+		Rows: roleRows,
+	})
+
+	Register(TableProvider{
+		Name:     ClusterRoleTableName,
+		Resource: ClusterRoleResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: ClusterRoleTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: ClusterRoleTableName},
+			{Name: "rules", Type: sql.Int32, Nullable: false, Source: ClusterRoleTableName},
+			{Name: "aggregated", Type: sql.Boolean, Nullable: false, Source: ClusterRoleTableName},
+			{Name: "labels", Type: sql.JSON, Nullable: false, Source: ClusterRoleTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: ClusterRoleTableName},
+		},
+		Rows: clusterRoleRows,
+	})
+
+	Register(TableProvider{
+		Name:     RoleBindingTableName,
+		Resource: RoleBindingResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: RoleBindingTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: RoleBindingTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: RoleBindingTableName},
+			{Name: "role_kind", Type: sql.Text, Nullable: false, Source: RoleBindingTableName},
+			{Name: "role_name", Type: sql.Text, Nullable: false, Source: RoleBindingTableName},
+			{Name: "subjects", Type: sql.Int32, Nullable: false, Source: RoleBindingTableName},
+			{Name: "labels", Type: sql.JSON, Nullable: false, Source: RoleBindingTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: RoleBindingTableName},
+		},
+		Rows: roleBindingRows,
+	})
+
+	Register(TableProvider{
+		Name:     ClusterRoleBindingTableName,
+		Resource: ClusterRoleBindingResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: ClusterRoleBindingTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: ClusterRoleBindingTableName},
+			{Name: "role_kind", Type: sql.Text, Nullable: false, Source: ClusterRoleBindingTableName},
+			{Name: "role_name", Type: sql.Text, Nullable: false, Source: ClusterRoleBindingTableName},
+			{Name: "subjects", Type: sql.Int32, Nullable: false, Source: ClusterRoleBindingTableName},
+			{Name: "labels", Type: sql.JSON, Nullable: false, Source: ClusterRoleBindingTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: ClusterRoleBindingTableName},
+		},
+		Rows: clusterRoleBindingRows,
+	})
+
+	Register(TableProvider{
+		Name:      RBACRuleTableName,
+		Resources: []Resource{RoleResource, ClusterRoleResource},
+		Schema: sql.Schema{
+			{Name: "role_uid", Type: sql.Text, Nullable: false, Source: RBACRuleTableName},
+			{Name: "role_kind", Type: sql.Text, Nullable: false, Source: RBACRuleTableName},
+			{Name: "role", Type: sql.Text, Nullable: false, Source: RBACRuleTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: RBACRuleTableName},
+			{Name: "api_group", Type: sql.Text, Nullable: true, Source: RBACRuleTableName},
+			{Name: "resource", Type: sql.Text, Nullable: true, Source: RBACRuleTableName},
+			{Name: "resource_name", Type: sql.Text, Nullable: true, Source: RBACRuleTableName},
+			{Name: "non_resource_url", Type: sql.Text, Nullable: true, Source: RBACRuleTableName},
+			{Name: "verb", Type: sql.Text, Nullable: false, Source: RBACRuleTableName},
+		},
+		Rows: rbacRuleRows,
+	})
+
+	Register(TableProvider{
+		Name:      RBACSubjectTableName,
+		Resources: []Resource{RoleBindingResource, ClusterRoleBindingResource},
+		Schema: sql.Schema{
+			{Name: "binding_uid", Type: sql.Text, Nullable: false, Source: RBACSubjectTableName},
+			{Name: "binding_kind", Type: sql.Text, Nullable: false, Source: RBACSubjectTableName},
+			{Name: "binding", Type: sql.Text, Nullable: false, Source: RBACSubjectTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: RBACSubjectTableName},
+			{Name: "role_kind", Type: sql.Text, Nullable: false, Source: RBACSubjectTableName},
+			{Name: "role_name", Type: sql.Text, Nullable: false, Source: RBACSubjectTableName},
+			{Name: "subject_kind", Type: sql.Text, Nullable: false, Source: RBACSubjectTableName},
+			{Name: "subject_name", Type: sql.Text, Nullable: false, Source: RBACSubjectTableName},
+			{Name: "subject_namespace", Type: sql.Text, Nullable: false, Source: RBACSubjectTableName},
+		},
+		Rows: rbacSubjectRows,
+	})
+}
+
+func serviceAccountRows(resource interface{}) ([]sql.Row, error) {
+	account, ok := resource.(*v1.ServiceAccount)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *v1.ServiceAccount but got %T", resource)
+	}
+
+	pullSecrets := make([]string, 0, len(account.ImagePullSecrets))
+	for _, secret := range account.ImagePullSecrets {
+		pullSecrets = append(pullSecrets, secret.Name)
+	}
+
+	return []sql.Row{sql.NewRow(string(account.UID), account.Name, account.Namespace,
+		nullableBool(account.AutomountServiceAccountToken),
+		strings.Join(pullSecrets, ","),
+		mapJSON(account.Labels),
+		account.CreationTimestamp.Time)}, nil
+}
+
+func roleRows(resource interface{}) ([]sql.Row, error) {
+	role, ok := resource.(*rbacv1.Role)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *rbacv1.Role but got %T", resource)
+	}
+	return []sql.Row{sql.NewRow(string(role.UID), role.Name, role.Namespace,
+		int32(len(role.Rules)),
+		mapJSON(role.Labels),
+		role.CreationTimestamp.Time)}, nil
+}
+
+func clusterRoleRows(resource interface{}) ([]sql.Row, error) {
+	role, ok := resource.(*rbacv1.ClusterRole)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *rbacv1.ClusterRole but got %T", resource)
+	}
+	return []sql.Row{sql.NewRow(string(role.UID), role.Name,
+		int32(len(role.Rules)),
+		boolValue(role.AggregationRule != nil),
+		mapJSON(role.Labels),
+		role.CreationTimestamp.Time)}, nil
+}
+
+func roleBindingRows(resource interface{}) ([]sql.Row, error) {
+	binding, ok := resource.(*rbacv1.RoleBinding)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *rbacv1.RoleBinding but got %T", resource)
+	}
+	return []sql.Row{sql.NewRow(string(binding.UID), binding.Name, binding.Namespace,
+		binding.RoleRef.Kind,
+		binding.RoleRef.Name,
+		int32(len(binding.Subjects)),
+		mapJSON(binding.Labels),
+		binding.CreationTimestamp.Time)}, nil
+}
+
+func clusterRoleBindingRows(resource interface{}) ([]sql.Row, error) {
+	binding, ok := resource.(*rbacv1.ClusterRoleBinding)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *rbacv1.ClusterRoleBinding but got %T", resource)
+	}
+	return []sql.Row{sql.NewRow(string(binding.UID), binding.Name,
+		binding.RoleRef.Kind,
+		binding.RoleRef.Name,
+		int32(len(binding.Subjects)),
+		mapJSON(binding.Labels),
+		binding.CreationTimestamp.Time)}, nil
+}
+
+// rbacRuleRows flattens the rules of Roles and ClusterRoles into a row per
+// api group, resource, resource name and verb, or per non-resource URL and
+// verb. Rules not restricted to resource names have a NULL resource_name.
+func rbacRuleRows(resource interface{}) ([]sql.Row, error) {
+	var rules []rbacv1.PolicyRule
+	var prefix sql.Row
+	switch role := resource.(type) {
+	case *rbacv1.Role:
+		rules = role.Rules
+		prefix = sql.NewRow(string(role.UID), "Role", role.Name, role.Namespace)
+	case *rbacv1.ClusterRole:
+		rules = role.Rules
+		prefix = sql.NewRow(string(role.UID), "ClusterRole", role.Name, "")
+	default:
+		return nil, fmt.Errorf("unexpected type for resource, expected *rbacv1.Role or *rbacv1.ClusterRole but got %T", resource)
+	}
+
+	rows := []sql.Row{}
+	for _, rule := range rules {
+		resourceNames := nullableStrings(rule.ResourceNames)
+		for _, verb := range rule.Verbs {
+			for _, group := range rule.APIGroups {
+				for _, name := range rule.Resources {
+					for _, resourceName := range resourceNames {
+						rows = append(rows, append(prefix.Copy(), group, name, resourceName, nil, verb))
+					}
+				}
+			}
+			for _, url := range rule.NonResourceURLs {
+				rows = append(rows, append(prefix.Copy(), nil, nil, nil, url, verb))
+			}
+		}
+	}
+	return rows, nil
+}
+
+// nullableStrings lists values, or a single NULL when there is none.
+func nullableStrings(values []string) []interface{} {
+	if len(values) == 0 {
+		return []interface{}{nil}
+	}
+	nullable := make([]interface{}, len(values))
+	for i, value := range values {
+		nullable[i] = value
+	}
+	return nullable
+}
+
+// rbacSubjectRows emits a row per subject of RoleBindings and
+// ClusterRoleBindings. ServiceAccount subjects without a namespace are in the
+// namespace of their RoleBinding.
+func rbacSubjectRows(resource interface{}) ([]sql.Row, error) {
+	var subjects []rbacv1.Subject
+	var prefix sql.Row
+	namespace := ""
+	switch binding := resource.(type) {
+	case *rbacv1.RoleBinding:
+		subjects = binding.Subjects
+		namespace = binding.Namespace
+		prefix = sql.NewRow(string(binding.UID), "RoleBinding", binding.Name, binding.Namespace,
+			binding.RoleRef.Kind, binding.RoleRef.Name)
+	case *rbacv1.ClusterRoleBinding:
+		subjects = binding.Subjects
+		prefix = sql.NewRow(string(binding.UID), "ClusterRoleBinding", binding.Name, "",
+			binding.RoleRef.Kind, binding.RoleRef.Name)
+	default:
+		return nil, fmt.Errorf("unexpected type for resource, expected *rbacv1.RoleBinding or *rbacv1.ClusterRoleBinding but got %T", resource)
+	}
+
+	rows := make([]sql.Row, 0, len(subjects))
+	for _, subject := range subjects {
+		subjectNamespace := subject.Namespace
+		if subjectNamespace == "" && subject.Kind == rbacv1.ServiceAccountKind {
+			subjectNamespace = namespace
+		}
+		rows = append(rows, append(prefix.Copy(), subject.Kind, subject.Name, subjectNamespace))
+	}
+	return rows, nil
+}
@@ -0,0 +1,50 @@
+package tables_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/adalrsjr1/sqlcluster/pkg/tables"
+	"github.com/dolthub/go-mysql-server/sql"
+	v1 "k8s.io/api/core/v1"
+)
+
+// TestRegister registers a table the way a binary embedding the server does,
+// from outside the package.
+func TestRegister(t *testing.T) {
+	tables.Register(tables.TableProvider{
+		Name:     "pod_priority",
+		Resource: tables.PodResource,
+		Schema: sql.Schema{
+			{Name: "pod_uid", Type: sql.Text, Nullable: false},
+			{Name: "priority_class", Type: sql.Text, Nullable: false},
+		},
+		Rows: func(resource interface{}) ([]sql.Row, error) {
+			pod, ok := resource.(*v1.Pod)
+			if !ok {
+				return nil, fmt.Errorf("unexpected type for resource, expected *v1.Pod but got %T", resource)
+			}
+			return []sql.Row{sql.NewRow(string(pod.UID), pod.Spec.PriorityClassName)}, nil
+		},
+	})
+
+	for _, provider := range tables.Providers() {
+		if provider.Name != "pod_priority" {
+			continue
+		}
+		for _, column := range provider.Schema {
+			if column.Source != "pod_priority" {
+				t.Errorf("column %s has source %q, want pod_priority", column.Name, column.Source)
+			}
+		}
+		rows, err := provider.Rows(&v1.Pod{Spec: v1.PodSpec{PriorityClassName: "critical"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rows) != 1 || rows[0][1] != "critical" {
+			t.Errorf("Rows() = %v, want a row with priority class critical", rows)
+		}
+		return
+	}
+	t.Error("registered table not listed by Providers")
+}
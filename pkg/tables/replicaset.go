@@ -0,0 +1,70 @@
+package tables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     ReplicaSetTableName,
+		Resource: ReplicaSetResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: ReplicaSetTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: ReplicaSetTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: ReplicaSetTableName},
+			{Name: "owner_uid", Type: sql.Text, Nullable: false, Source: ReplicaSetTableName},
+			{Name: "desired_replicas", Type: sql.Int32, Nullable: false, Source: ReplicaSetTableName},
+			{Name: "replicas", Type: sql.Int32, Nullable: false, Source: ReplicaSetTableName},
+			{Name: "ready_replicas", Type: sql.Int32, Nullable: false, Source: ReplicaSetTableName},
+			{Name: "available_replicas", Type: sql.Int32, Nullable: false, Source: ReplicaSetTableName},
+			{Name: "updated_replicas", Type: sql.Int32, Nullable: true, Source: ReplicaSetTableName},
+			{Name: "strategy", Type: sql.Text, Nullable: false, Source: ReplicaSetTableName},
+			{Name: "selector", Type: sql.Text, Nullable: false, Source: ReplicaSetTableName},
+			{Name: "generation", Type: sql.Int64, Nullable: false, Source: ReplicaSetTableName},
+			{Name: "observed_generation", Type: sql.Int64, Nullable: false, Source: ReplicaSetTableName},
+			{Name: "labels", Type: sql.JSON, Nullable: false, Source: ReplicaSetTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: ReplicaSetTableName},
+		},
+		Rows: replicaSetRows,
+	})
+}
+
+func replicaSetRows(resource interface{}) ([]sql.Row, error) {
+	replicaSet, ok := resource.(*appsv1.ReplicaSet)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *appsv1.ReplicaSet but got %T", resource)
+	}
+	return []sql.Row{replicaSetRow(replicaSet)}, nil
+}
+
+// replicaSetRow leaves updated_replicas NULL, as a ReplicaSet does not track
+// updates, and strategy empty as ReplicaSets are rolled out by their
+// Deployment.
+func replicaSetRow(replicaSet *appsv1.ReplicaSet) sql.Row {
+	return sql.NewRow(string(replicaSet.UID), replicaSet.Name, replicaSet.Namespace,
+		controllerUID(&replicaSet.ObjectMeta),
+		desiredReplicas(replicaSet.Spec.Replicas),
+		replicaSet.Status.Replicas,
+		replicaSet.Status.ReadyReplicas,
+		replicaSet.Status.AvailableReplicas,
+		nil,
+		"",
+		metav1.FormatLabelSelector(replicaSet.Spec.Selector),
+		replicaSet.Generation,
+		replicaSet.Status.ObservedGeneration,
+		mapJSON(replicaSet.Labels),
+		replicaSet.CreationTimestamp.Time)
+}
+
+// controllerUID returns the uid of the controller owning obj, or an empty
+// string when obj has no controller.
+func controllerUID(obj *metav1.ObjectMeta) string {
+	if owner := metav1.GetControllerOfNoCopy(obj); owner != nil {
+		return string(owner.UID)
+	}
+	return ""
+}
@@ -0,0 +1,148 @@
+package tables
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     ResourceQuotaTableName,
+		Resource: ResourceQuotaResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: ResourceQuotaTableName},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: ResourceQuotaTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: ResourceQuotaTableName},
+			{Name: "resource", Type: sql.Text, Nullable: false, Source: ResourceQuotaTableName},
+			{Name: "hard", Type: sql.Int64, Nullable: true, Source: ResourceQuotaTableName},
+			{Name: "used", Type: sql.Int64, Nullable: true, Source: ResourceQuotaTableName},
+			{Name: "scopes", Type: sql.Text, Nullable: false, Source: ResourceQuotaTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: ResourceQuotaTableName},
+		},
+		Rows: resourceQuotaRows,
+	})
+
+	Register(TableProvider{
+		Name:     LimitRangeTableName,
+		Resource: LimitRangeResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: LimitRangeTableName},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: LimitRangeTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: LimitRangeTableName},
+			{Name: "type", Type: sql.Text, Nullable: false, Source: LimitRangeTableName},
+			{Name: "resource", Type: sql.Text, Nullable: false, Source: LimitRangeTableName},
+			{Name: "min_value", Type: sql.Int64, Nullable: true, Source: LimitRangeTableName},
+			{Name: "max_value", Type: sql.Int64, Nullable: true, Source: LimitRangeTableName},
+			{Name: "default_limit", Type: sql.Int64, Nullable: true, Source: LimitRangeTableName},
+			{Name: "default_request", Type: sql.Int64, Nullable: true, Source: LimitRangeTableName},
+			{Name: "max_limit_request_ratio", Type: sql.Float64, Nullable: true, Source: LimitRangeTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: LimitRangeTableName},
+		},
+		Rows: limitRangeRows,
+	})
+}
+
+// resourceQuotaRows emits a row per resource named by the quota, with the
+// hard limit and current usage in the units of the container table.
+func resourceQuotaRows(obj interface{}) ([]sql.Row, error) {
+	quota, ok := obj.(*v1.ResourceQuota)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *v1.ResourceQuota but got %T", obj)
+	}
+
+	scopes := make([]string, 0, len(quota.Spec.Scopes))
+	for _, scope := range quota.Spec.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	names := resourceNames(quota.Status.Hard, quota.Status.Used, quota.Spec.Hard)
+	rows := make([]sql.Row, 0, len(names))
+	for _, name := range names {
+		hard, ok := quota.Status.Hard[name]
+		if !ok {
+			hard, ok = quota.Spec.Hard[name]
+		}
+		hardValue := nullableQuantity(name, hard, ok)
+		used, ok := quota.Status.Used[name]
+		rows = append(rows, sql.NewRow(string(quota.UID), quota.Name, quota.Namespace,
+			string(name),
+			hardValue,
+			nullableQuantity(name, used, ok),
+			strings.Join(scopes, ","),
+			quota.CreationTimestamp.Time))
+	}
+	return rows, nil
+}
+
+// limitRangeRows emits a row per limit type and resource constrained by the
+// limit range.
+func limitRangeRows(obj interface{}) ([]sql.Row, error) {
+	limitRange, ok := obj.(*v1.LimitRange)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *v1.LimitRange but got %T", obj)
+	}
+
+	rows := []sql.Row{}
+	for _, item := range limitRange.Spec.Limits {
+		for _, name := range resourceNames(item.Min, item.Max, item.Default, item.DefaultRequest, item.MaxLimitRequestRatio) {
+			min, hasMin := item.Min[name]
+			max, hasMax := item.Max[name]
+			def, hasDefault := item.Default[name]
+			defRequest, hasDefaultRequest := item.DefaultRequest[name]
+
+			var ratio interface{}
+			if q, ok := item.MaxLimitRequestRatio[name]; ok {
+				ratio = q.AsApproximateFloat64()
+			}
+
+			rows = append(rows, sql.NewRow(string(limitRange.UID), limitRange.Name, limitRange.Namespace,
+				string(item.Type),
+				string(name),
+				nullableQuantity(name, min, hasMin),
+				nullableQuantity(name, max, hasMax),
+				nullableQuantity(name, def, hasDefault),
+				nullableQuantity(name, defRequest, hasDefaultRequest),
+				ratio,
+				limitRange.CreationTimestamp.Time))
+		}
+	}
+	return rows, nil
+}
+
+// quantityValue converts a quantity to the unit used across tables: CPU in
+// millicores and everything else in its base unit, such as bytes or counts.
+func quantityValue(name v1.ResourceName, q resource.Quantity) int64 {
+	switch name {
+	case v1.ResourceCPU, v1.ResourceRequestsCPU, v1.ResourceLimitsCPU:
+		return q.MilliValue()
+	default:
+		return q.Value()
+	}
+}
+
+func nullableQuantity(name v1.ResourceName, q resource.Quantity, ok bool) interface{} {
+	if !ok {
+		return nil
+	}
+	return quantityValue(name, q)
+}
+
+func resourceNames(lists ...v1.ResourceList) []v1.ResourceName {
+	seen := map[v1.ResourceName]bool{}
+	names := []v1.ResourceName{}
+	for _, list := range lists {
+		for name := range list {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
@@ -0,0 +1,74 @@
+package tables
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/cache"
+)
+
+// retainedStore keeps the objects deleted from an informer cache until their
+// retention expires, so tables can still project them once the API server has
+// garbage collected them.
+type retainedStore struct {
+	mu        sync.Mutex
+	retention time.Duration
+	retain    func(obj interface{}) bool
+	deleted   map[string]retainedObject
+}
+
+type retainedObject struct {
+	obj       interface{}
+	deletedAt time.Time
+}
+
+func newRetainedStore(retention time.Duration, retain func(obj interface{}) bool) *retainedStore {
+	return &retainedStore{
+		retention: retention,
+		retain:    retain,
+		deleted:   map[string]retainedObject{},
+	}
+}
+
+// Add retains a deleted object. Objects are keyed by uid, so a recreated
+// object with the same name does not hide its deleted predecessor.
+func (r *retainedStore) Add(obj interface{}) {
+	if deleted, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = deleted.Obj
+	}
+	if r.retain != nil && !r.retain(obj) {
+		return
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		log.Warnf("cannot retain deleted object of type %T: %v", obj, err)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.prune(time.Now())
+	r.deleted[string(accessor.GetUID())] = retainedObject{obj: obj, deletedAt: time.Now()}
+}
+
+// List returns the deleted objects still retained.
+func (r *retainedStore) List() []interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.prune(time.Now())
+
+	objects := make([]interface{}, 0, len(r.deleted))
+	for _, retained := range r.deleted {
+		objects = append(objects, retained.obj)
+	}
+	return objects
+}
+
+func (r *retainedStore) prune(now time.Time) {
+	for uid, retained := range r.deleted {
+		if now.Sub(retained.deletedAt) > r.retention {
+			delete(r.deleted, uid)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package tables
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     ServiceTableName,
+		Resource: ServiceResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: ServiceTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: ServiceTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: ServiceTableName},
+			{Name: "type", Type: sql.Text, Nullable: false, Source: ServiceTableName},
+			{Name: "cluster_ip", Type: sql.Text, Nullable: false, Source: ServiceTableName},
+			{Name: "cluster_ips", Type: sql.Text, Nullable: false, Source: ServiceTableName},
+			{Name: "external_ips", Type: sql.Text, Nullable: false, Source: ServiceTableName},
+			{Name: "external_name", Type: sql.Text, Nullable: false, Source: ServiceTableName},
+			{Name: "selector", Type: sql.Text, Nullable: false, Source: ServiceTableName},
+			{Name: "session_affinity", Type: sql.Text, Nullable: false, Source: ServiceTableName},
+			{Name: "ports", Type: sql.Text, Nullable: false, Source: ServiceTableName},
+			{Name: "labels", Type: sql.JSON, Nullable: false, Source: ServiceTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: ServiceTableName},
+		},
+		Rows: serviceRows,
+	})
+}
+
+func serviceRows(resource interface{}) ([]sql.Row, error) {
+	svc, ok := resource.(*v1.Service)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *v1.Service but got %T", resource)
+	}
+	return []sql.Row{serviceRow(svc)}, nil
+}
+
+func serviceRow(svc *v1.Service) sql.Row {
+	return sql.NewRow(string(svc.UID), svc.Name, svc.Namespace, string(svc.Spec.Type),
+		svc.Spec.ClusterIP,
+		strings.Join(svc.Spec.ClusterIPs, ","),
+		strings.Join(svc.Spec.ExternalIPs, ","),
+		svc.Spec.ExternalName,
+		labels.FormatLabels(svc.Spec.Selector),
+		string(svc.Spec.SessionAffinity),
+		servicePorts(svc.Spec.Ports),
+		mapJSON(svc.Labels),
+		svc.CreationTimestamp.Time)
+}
+
+// servicePorts formats ports as kubectl does, e.g. "80/TCP,443:30443/TCP".
+func servicePorts(ports []v1.ServicePort) string {
+	formatted := make([]string, 0, len(ports))
+	for _, port := range ports {
+		if port.NodePort != 0 {
+			formatted = append(formatted, fmt.Sprintf("%d:%d/%s", port.Port, port.NodePort, port.Protocol))
+		} else {
+			formatted = append(formatted, fmt.Sprintf("%d/%s", port.Port, port.Protocol))
+		}
+	}
+	return strings.Join(formatted, ",")
+}
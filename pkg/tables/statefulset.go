@@ -0,0 +1,56 @@
+package tables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     StatefulSetTableName,
+		Resource: StatefulSetResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: StatefulSetTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: StatefulSetTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: StatefulSetTableName},
+			{Name: "desired_replicas", Type: sql.Int32, Nullable: false, Source: StatefulSetTableName},
+			{Name: "replicas", Type: sql.Int32, Nullable: false, Source: StatefulSetTableName},
+			{Name: "ready_replicas", Type: sql.Int32, Nullable: false, Source: StatefulSetTableName},
+			{Name: "available_replicas", Type: sql.Int32, Nullable: false, Source: StatefulSetTableName},
+			{Name: "updated_replicas", Type: sql.Int32, Nullable: false, Source: StatefulSetTableName},
+			{Name: "strategy", Type: sql.Text, Nullable: false, Source: StatefulSetTableName},
+			{Name: "selector", Type: sql.Text, Nullable: false, Source: StatefulSetTableName},
+			{Name: "generation", Type: sql.Int64, Nullable: false, Source: StatefulSetTableName},
+			{Name: "observed_generation", Type: sql.Int64, Nullable: false, Source: StatefulSetTableName},
+			{Name: "labels", Type: sql.JSON, Nullable: false, Source: StatefulSetTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: StatefulSetTableName},
+		},
+		Rows: statefulSetRows,
+	})
+}
+
+func statefulSetRows(resource interface{}) ([]sql.Row, error) {
+	statefulSet, ok := resource.(*appsv1.StatefulSet)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *appsv1.StatefulSet but got %T", resource)
+	}
+	return []sql.Row{statefulSetRow(statefulSet)}, nil
+}
+
+func statefulSetRow(statefulSet *appsv1.StatefulSet) sql.Row {
+	return sql.NewRow(string(statefulSet.UID), statefulSet.Name, statefulSet.Namespace,
+		desiredReplicas(statefulSet.Spec.Replicas),
+		statefulSet.Status.Replicas,
+		statefulSet.Status.ReadyReplicas,
+		statefulSet.Status.AvailableReplicas,
+		statefulSet.Status.UpdatedReplicas,
+		string(statefulSet.Spec.UpdateStrategy.Type),
+		metav1.FormatLabelSelector(statefulSet.Spec.Selector),
+		statefulSet.Generation,
+		statefulSet.Status.ObservedGeneration,
+		mapJSON(statefulSet.Labels),
+		statefulSet.CreationTimestamp.Time)
+}
@@ -0,0 +1,211 @@
+package tables
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+)
+
+const defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+func init() {
+	Register(TableProvider{
+		Name:     PersistentVolumeTableName,
+		Resource: PersistentVolumeResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: PersistentVolumeTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: PersistentVolumeTableName},
+			{Name: "type", Type: sql.Text, Nullable: false, Source: PersistentVolumeTableName},
+			{Name: "driver", Type: sql.Text, Nullable: false, Source: PersistentVolumeTableName},
+			{Name: "capacity", Type: sql.Int64, Nullable: false, Source: PersistentVolumeTableName},
+			{Name: "access_modes", Type: sql.Text, Nullable: false, Source: PersistentVolumeTableName},
+			{Name: "volume_mode", Type: sql.Text, Nullable: false, Source: PersistentVolumeTableName},
+			{Name: "reclaim_policy", Type: sql.Text, Nullable: false, Source: PersistentVolumeTableName},
+			{Name: "storage_class", Type: sql.Text, Nullable: false, Source: PersistentVolumeTableName},
+			{Name: "phase", Type: sql.Text, Nullable: false, Source: PersistentVolumeTableName},
+			{Name: "claim_uid", Type: sql.Text, Nullable: false, Source: PersistentVolumeTableName},
+			{Name: "claim_namespace", Type: sql.Text, Nullable: false, Source: PersistentVolumeTableName},
+			{Name: "claim_name", Type: sql.Text, Nullable: false, Source: PersistentVolumeTableName},
+			{Name: "zone", Type: sql.Text, Nullable: false, Source: PersistentVolumeTableName},
+			{Name: "labels", Type: sql.JSON, Nullable: false, Source: PersistentVolumeTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: PersistentVolumeTableName},
+		},
+		Rows: persistentVolumeRows,
+	})
+
+	Register(TableProvider{
+		Name:     PVCTableName,
+		Resource: PVCResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: PVCTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: PVCTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: PVCTableName},
+			{Name: "phase", Type: sql.Text, Nullable: false, Source: PVCTableName},
+			{Name: "volume_name", Type: sql.Text, Nullable: false, Source: PVCTableName},
+			{Name: "storage_class", Type: sql.Text, Nullable: false, Source: PVCTableName},
+			{Name: "access_modes", Type: sql.Text, Nullable: false, Source: PVCTableName},
+			{Name: "volume_mode", Type: sql.Text, Nullable: false, Source: PVCTableName},
+			{Name: "requested", Type: sql.Int64, Nullable: false, Source: PVCTableName},
+			{Name: "capacity", Type: sql.Int64, Nullable: true, Source: PVCTableName},
+			{Name: "labels", Type: sql.JSON, Nullable: false, Source: PVCTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: PVCTableName},
+		},
+		Rows: pvcRows,
+	})
+
+	Register(TableProvider{
+		Name:     StorageClassTableName,
+		Resource: StorageClassResource,
+		Schema: sql.Schema{
+			{Name: "uid", Type: sql.Text, Nullable: false, Source: StorageClassTableName, PrimaryKey: true},
+			{Name: "name", Type: sql.Text, Nullable: false, Source: StorageClassTableName},
+			{Name: "provisioner", Type: sql.Text, Nullable: false, Source: StorageClassTableName},
+			{Name: "reclaim_policy", Type: sql.Text, Nullable: false, Source: StorageClassTableName},
+			{Name: "volume_binding_mode", Type: sql.Text, Nullable: false, Source: StorageClassTableName},
+			{Name: "allow_volume_expansion", Type: sql.Boolean, Nullable: false, Source: StorageClassTableName},
+			{Name: "is_default", Type: sql.Boolean, Nullable: false, Source: StorageClassTableName},
+			{Name: "parameters", Type: sql.JSON, Nullable: false, Source: StorageClassTableName},
+			{Name: "created_at", Type: sql.Datetime, Nullable: false, Source: StorageClassTableName},
+		},
+		Rows: storageClassRows,
+	})
+}
+
+func persistentVolumeRows(resource interface{}) ([]sql.Row, error) {
+	pv, ok := resource.(*v1.PersistentVolume)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *v1.PersistentVolume but got %T", resource)
+	}
+
+	var claimUID, claimNamespace, claimName string
+	if ref := pv.Spec.ClaimRef; ref != nil {
+		claimUID, claimNamespace, claimName = string(ref.UID), ref.Namespace, ref.Name
+	}
+
+	driver := ""
+	if pv.Spec.CSI != nil {
+		driver = pv.Spec.CSI.Driver
+	}
+
+	return []sql.Row{sql.NewRow(string(pv.UID), pv.Name,
+		volumeSourceType(pv.Spec.PersistentVolumeSource),
+		driver,
+		pv.Spec.Capacity.Storage().Value(),
+		accessModes(pv.Spec.AccessModes),
+		volumeMode(pv.Spec.VolumeMode),
+		string(pv.Spec.PersistentVolumeReclaimPolicy),
+		pv.Spec.StorageClassName,
+		string(pv.Status.Phase),
+		claimUID,
+		claimNamespace,
+		claimName,
+		persistentVolumeZone(pv),
+		mapJSON(pv.Labels),
+		pv.CreationTimestamp.Time)}, nil
+}
+
+func pvcRows(resource interface{}) ([]sql.Row, error) {
+	pvc, ok := resource.(*v1.PersistentVolumeClaim)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *v1.PersistentVolumeClaim but got %T", resource)
+	}
+
+	var capacity interface{}
+	if storage, ok := pvc.Status.Capacity[v1.ResourceStorage]; ok {
+		capacity = storage.Value()
+	}
+
+	return []sql.Row{sql.NewRow(string(pvc.UID), pvc.Name, pvc.Namespace,
+		string(pvc.Status.Phase),
+		pvc.Spec.VolumeName,
+		stringValue(pvc.Spec.StorageClassName),
+		accessModes(pvc.Spec.AccessModes),
+		volumeMode(pvc.Spec.VolumeMode),
+		pvc.Spec.Resources.Requests.Storage().Value(),
+		capacity,
+		mapJSON(pvc.Labels),
+		pvc.CreationTimestamp.Time)}, nil
+}
+
+func storageClassRows(resource interface{}) ([]sql.Row, error) {
+	class, ok := resource.(*storagev1.StorageClass)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *storagev1.StorageClass but got %T", resource)
+	}
+
+	reclaimPolicy := v1.PersistentVolumeReclaimDelete
+	if class.ReclaimPolicy != nil {
+		reclaimPolicy = *class.ReclaimPolicy
+	}
+	bindingMode := storagev1.VolumeBindingImmediate
+	if class.VolumeBindingMode != nil {
+		bindingMode = *class.VolumeBindingMode
+	}
+	allowExpansion := class.AllowVolumeExpansion != nil && *class.AllowVolumeExpansion
+
+	return []sql.Row{sql.NewRow(string(class.UID), class.Name,
+		class.Provisioner,
+		string(reclaimPolicy),
+		string(bindingMode),
+		boolValue(allowExpansion),
+		boolValue(class.Annotations[defaultStorageClassAnnotation] == "true"),
+		mapJSON(class.Parameters),
+		class.CreationTimestamp.Time)}, nil
+}
+
+// persistentVolumeZone reads the zone of a volume from its topology labels,
+// or else from the zones its required node affinity is restricted to.
+func persistentVolumeZone(pv *v1.PersistentVolume) string {
+	if zone := topologyLabel(pv.Labels, v1.LabelTopologyZone, v1.LabelFailureDomainBetaZone); zone != "" {
+		return zone
+	}
+	if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return ""
+	}
+
+	zones := []string{}
+	for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+		for _, expression := range term.MatchExpressions {
+			if expression.Operator != v1.NodeSelectorOpIn {
+				continue
+			}
+			if expression.Key == v1.LabelTopologyZone || expression.Key == v1.LabelFailureDomainBetaZone {
+				zones = append(zones, expression.Values...)
+			}
+		}
+	}
+	return strings.Join(zones, ",")
+}
+
+// volumeSourceType names the source set in a volume source struct, such as
+// v1.VolumeSource, after its JSON field, e.g. persistentVolumeClaim or csi.
+func volumeSourceType(source interface{}) string {
+	value := reflect.ValueOf(source)
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if field.Kind() == reflect.Ptr && !field.IsNil() {
+			return strings.Split(value.Type().Field(i).Tag.Get("json"), ",")[0]
+		}
+	}
+	return ""
+}
+
+func accessModes(modes []v1.PersistentVolumeAccessMode) string {
+	names := make([]string, 0, len(modes))
+	for _, mode := range modes {
+		names = append(names, string(mode))
+	}
+	return strings.Join(names, ",")
+}
+
+// volumeMode resolves an unset volume mode to the API default, Filesystem.
+func volumeMode(mode *v1.PersistentVolumeMode) string {
+	if mode == nil {
+		return string(v1.PersistentVolumeFilesystem)
+	}
+	return string(*mode)
+}
@@ -0,0 +1,117 @@
+package tables
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/sirupsen/logrus"
+)
+
+// objectLister is the part of cache.Store read by a storeTable.
+type objectLister interface {
+	List() []interface{}
+}
+
+// storeTable is a sql.Table backed by informer stores. Rows are projected
+// from the cached objects while the table is scanned, so queries always see
+// the current state of the cache and nothing is copied in memory. Each store
+// is scanned as its own partition.
+type storeTable struct {
+	provider TableProvider
+	stores   map[string]objectLister
+	logger   *logrus.Entry
+}
+
+var _ sql.Table = (*storeTable)(nil)
+
+func newStoreTable(provider TableProvider, stores map[string]objectLister) *storeTable {
+	return &storeTable{
+		provider: provider,
+		stores:   stores,
+		logger:   tableLogger(provider.Name),
+	}
+}
+
+func (t *storeTable) Name() string {
+	return t.provider.Name
+}
+
+func (t *storeTable) String() string {
+	return t.provider.Name
+}
+
+func (t *storeTable) Schema() sql.Schema {
+	return t.provider.Schema
+}
+
+func (t *storeTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+func (t *storeTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	partitions := make([]sql.Partition, 0, len(t.stores))
+	for name := range t.stores {
+		partitions = append(partitions, storePartition(name))
+	}
+	sort.Slice(partitions, func(i, j int) bool {
+		return partitions[i].(storePartition) < partitions[j].(storePartition)
+	})
+	return sql.PartitionsToPartitionIter(partitions...), nil
+}
+
+func (t *storeTable) PartitionRows(_ *sql.Context, partition sql.Partition) (sql.RowIter, error) {
+	store, ok := t.stores[string(partition.Key())]
+	if !ok {
+		return nil, fmt.Errorf("table %s has no partition %s", t.provider.Name, partition.Key())
+	}
+	return &storeRowIter{
+		objects: store.List(),
+		project: t.provider.Rows,
+		logger:  t.logger,
+	}, nil
+}
+
+// storePartition names the store backing a partition.
+type storePartition string
+
+func (p storePartition) Key() []byte {
+	return []byte(p)
+}
+
+// storeRowIter projects the objects of a store snapshot one at a time.
+type storeRowIter struct {
+	objects []interface{}
+	project func(obj interface{}) ([]sql.Row, error)
+	pending []sql.Row
+	logger  *logrus.Entry
+}
+
+func (i *storeRowIter) Next(*sql.Context) (sql.Row, error) {
+	for len(i.pending) == 0 {
+		if len(i.objects) == 0 {
+			return nil, io.EOF
+		}
+
+		obj := i.objects[0]
+		i.objects = i.objects[1:]
+
+		rows, err := i.project(obj)
+		if err != nil {
+			i.logger.Error(err)
+			continue
+		}
+		i.pending = rows
+	}
+
+	row := i.pending[0]
+	i.pending = i.pending[1:]
+	return row, nil
+}
+
+func (i *storeRowIter) Close(*sql.Context) error {
+	i.objects = nil
+	i.pending = nil
+	return nil
+}
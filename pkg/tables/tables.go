@@ -0,0 +1,244 @@
+package tables
+
+import (
+	"time"
+
+	"github.com/adalrsjr1/sqlcluster/internal/services"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	k8srt "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+const (
+	AffinityTableName             = "affinity"
+	NodeAffinityTableName         = "node_affinity"
+	NodeMetricsTableName          = "node_metrics"
+	PodMetricsTableName           = "pod_metrics"
+	PodTableName                  = "pod"
+	EndpointTableName             = "endpoint"
+	NodeTableName                 = "node"
+	ContainerTableName            = "container"
+	TrafficTableName              = "traffic"
+	DeploymentTableName           = "deployment"
+	ReplicaSetTableName           = "replicaset"
+	StatefulSetTableName          = "statefulset"
+	DaemonSetTableName            = "daemonset"
+	OwnerRefTableName             = "owner_reference"
+	ServiceTableName              = "service"
+	EndpointSliceTableName        = "endpoint_slice"
+	EventTableName                = "event"
+	ContainerStatusTableName      = "container_status"
+	ContainerTerminationTableName = "container_termination"
+	PodConditionTableName         = "pod_condition"
+	LabelTableName                = "label"
+	AnnotationTableName           = "annotation"
+	NodeConditionTableName        = "node_condition"
+	NodeTaintTableName            = "node_taint"
+	NodeAddressTableName          = "node_address"
+	PodTolerationTableName        = "pod_toleration"
+	PodNodeSelectorTableName      = "pod_node_selector"
+	TopologySpreadTableName       = "topology_spread_constraint"
+	TopologySkewTableName         = "topology_skew"
+	NamespaceTableName            = "namespace"
+	ResourceQuotaTableName        = "resource_quota"
+	LimitRangeTableName           = "limit_range"
+	PersistentVolumeTableName     = "persistent_volume"
+	PVCTableName                  = "persistent_volume_claim"
+	StorageClassTableName         = "storage_class"
+	PodVolumeTableName            = "pod_volume"
+	JobTableName                  = "job"
+	CronJobTableName              = "cronjob"
+	HPATableName                  = "hpa"
+	HPAMetricTableName            = "hpa_metric"
+	HPAConditionTableName         = "hpa_condition"
+	PDBTableName                  = "pdb"
+	IngressTableName              = "ingress"
+	IngressRuleTableName          = "ingress_rule"
+	GatewayTableName              = "gateway"
+	HTTPRouteTableName            = "http_route"
+	NetworkPolicyTableName        = "network_policy"
+	NetworkPolicyRuleTableName    = "network_policy_rule"
+	ServiceAccountTableName       = "service_account"
+	RoleTableName                 = "role"
+	ClusterRoleTableName          = "cluster_role"
+	RoleBindingTableName          = "role_binding"
+	ClusterRoleBindingTableName   = "cluster_role_binding"
+	RBACRuleTableName             = "rbac_rule"
+	RBACSubjectTableName          = "rbac_subject"
+	VirtualServiceRouteTableName  = "virtual_service_route"
+	DestinationRuleTableName      = "destination_rule"
+	ServiceEntryTableName         = "service_entry"
+	PeerAuthenticationTableName   = "peer_authentication"
+	AuthorizationPolicyTableName  = "authorization_policy"
+)
+
+// Resource identifies a watched Kubernetes resource. Every table projecting
+// from the same Resource shares a single informer and its cache.
+type Resource string
+
+const (
+	// AnyResource projects a table from every resource watched by the server.
+	AnyResource Resource = "*"
+
+	PodResource                 Resource = "pods"
+	NodeResource                Resource = "nodes"
+	EndpointsResource           Resource = "endpoints"
+	PodMetricsResource          Resource = "pods.metrics.k8s.io"
+	NodeMetricsResource         Resource = "nodes.metrics.k8s.io"
+	DeploymentResource          Resource = "deployments.apps"
+	ReplicaSetResource          Resource = "replicasets.apps"
+	StatefulSetResource         Resource = "statefulsets.apps"
+	DaemonSetResource           Resource = "daemonsets.apps"
+	ServiceResource             Resource = "services"
+	EndpointSliceResource       Resource = "endpointslices.discovery.k8s.io"
+	EventResource               Resource = "events"
+	NamespaceResource           Resource = "namespaces"
+	ResourceQuotaResource       Resource = "resourcequotas"
+	LimitRangeResource          Resource = "limitranges"
+	PersistentVolumeResource    Resource = "persistentvolumes"
+	PVCResource                 Resource = "persistentvolumeclaims"
+	StorageClassResource        Resource = "storageclasses.storage.k8s.io"
+	JobResource                 Resource = "jobs.batch"
+	CronJobResource             Resource = "cronjobs.batch"
+	HPAResource                 Resource = "horizontalpodautoscalers.autoscaling"
+	PDBResource                 Resource = "poddisruptionbudgets.policy"
+	IngressResource             Resource = "ingresses.networking.k8s.io"
+	NetworkPolicyResource       Resource = "networkpolicies.networking.k8s.io"
+	GatewayResource             Resource = "gateways.gateway.networking.k8s.io"
+	HTTPRouteResource           Resource = "httproutes.gateway.networking.k8s.io"
+	ServiceAccountResource      Resource = "serviceaccounts"
+	RoleResource                Resource = "roles.rbac.authorization.k8s.io"
+	ClusterRoleResource         Resource = "clusterroles.rbac.authorization.k8s.io"
+	RoleBindingResource         Resource = "rolebindings.rbac.authorization.k8s.io"
+	ClusterRoleBindingResource  Resource = "clusterrolebindings.rbac.authorization.k8s.io"
+	VirtualServiceResource      Resource = "virtualservices.networking.istio.io"
+	DestinationRuleResource     Resource = "destinationrules.networking.istio.io"
+	ServiceEntryResource        Resource = "serviceentries.networking.istio.io"
+	PeerAuthenticationResource  Resource = "peerauthentications.security.istio.io"
+	AuthorizationPolicyResource Resource = "authorizationpolicies.security.istio.io"
+)
+
+func init() {
+	RegisterResource(PodResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Core().V1().Pods().Informer()
+	})
+	RegisterResource(NodeResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Core().V1().Nodes().Informer()
+	})
+	RegisterResource(EndpointsResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Core().V1().Endpoints().Informer()
+	})
+	RegisterResource(DeploymentResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Apps().V1().Deployments().Informer()
+	})
+	RegisterResource(ReplicaSetResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Apps().V1().ReplicaSets().Informer()
+	})
+	RegisterResource(StatefulSetResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Apps().V1().StatefulSets().Informer()
+	})
+	RegisterResource(DaemonSetResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Apps().V1().DaemonSets().Informer()
+	})
+	RegisterResource(ServiceResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Core().V1().Services().Informer()
+	})
+	RegisterResource(EndpointSliceResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Discovery().V1().EndpointSlices().Informer()
+	})
+	RegisterResource(NamespaceResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Core().V1().Namespaces().Informer()
+	})
+	RegisterResource(ResourceQuotaResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Core().V1().ResourceQuotas().Informer()
+	})
+	RegisterResource(LimitRangeResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Core().V1().LimitRanges().Informer()
+	})
+	RegisterResource(PersistentVolumeResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Core().V1().PersistentVolumes().Informer()
+	})
+	RegisterResource(PVCResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Core().V1().PersistentVolumeClaims().Informer()
+	})
+	RegisterResource(StorageClassResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Storage().V1().StorageClasses().Informer()
+	})
+	RegisterResource(JobResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Batch().V1().Jobs().Informer()
+	})
+	RegisterResource(CronJobResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Batch().V1().CronJobs().Informer()
+	})
+	RegisterResource(HPAResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Autoscaling().V2().HorizontalPodAutoscalers().Informer()
+	})
+	RegisterResource(PDBResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Policy().V1().PodDisruptionBudgets().Informer()
+	})
+	RegisterResource(IngressResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Networking().V1().Ingresses().Informer()
+	})
+	RegisterResource(NetworkPolicyResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Networking().V1().NetworkPolicies().Informer()
+	})
+	RegisterResource(ServiceAccountResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Core().V1().ServiceAccounts().Informer()
+	})
+	RegisterResource(RoleResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Rbac().V1().Roles().Informer()
+	})
+	RegisterResource(ClusterRoleResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Rbac().V1().ClusterRoles().Informer()
+	})
+	RegisterResource(RoleBindingResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Rbac().V1().RoleBindings().Informer()
+	})
+	RegisterResource(ClusterRoleBindingResource, func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.Rbac().V1().ClusterRoleBindings().Informer()
+	})
+	RegisterDynamicResource(GatewayResource,
+		schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gateways"},
+		schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1beta1", Resource: "gateways"})
+	RegisterDynamicResource(HTTPRouteResource,
+		schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes"},
+		schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1beta1", Resource: "httproutes"})
+	for resource, gvrs := range map[Resource][]schema.GroupVersionResource{
+		VirtualServiceResource:      istioVersions("networking.istio.io", "virtualservices"),
+		DestinationRuleResource:     istioVersions("networking.istio.io", "destinationrules"),
+		ServiceEntryResource:        istioVersions("networking.istio.io", "serviceentries"),
+		PeerAuthenticationResource:  istioVersions("security.istio.io", "peerauthentications"),
+		AuthorizationPolicyResource: istioVersions("security.istio.io", "authorizationpolicies"),
+	} {
+		RegisterDynamicResource(resource, gvrs...)
+	}
+	RegisterResource(EventResource, eventInformer)
+	RegisterResource(PodMetricsResource, metricsInformer(&v1beta1.PodMetrics{}, "pods"))
+	RegisterResource(NodeMetricsResource, metricsInformer(&v1beta1.NodeMetrics{}, "nodes"))
+}
+
+// metricsInformer registers a metrics.k8s.io informer in the shared factory,
+// so it is started and cached like any typed informer.
+func metricsInformer(informerType k8srt.Object, resourceType string) func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+	return func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return factory.InformerFor(informerType, func(_ kubernetes.Interface, resync time.Duration) cache.SharedIndexInformer {
+			watchList := cache.NewListWatchFromClient(services.ClientsetVS.MetricsV1beta1().RESTClient(),
+				resourceType, v1.NamespaceAll, fields.Everything())
+			return cache.NewSharedIndexInformer(watchList, informerType, resync, cache.Indexers{})
+		})
+	}
+}
+
+func tableLogger(table string) *logrus.Entry {
+	return logrus.New().WithField("table", table)
+}
+
+var (
+	log = *logrus.New().WithField("pkg", "tables")
+)
@@ -0,0 +1,39 @@
+package tables
+
+import (
+	"fmt"
+	"testing"
+
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// compatColumns are reserved words kept as column names for compatibility
+// with existing queries. They must be quoted, e.g. `window`.
+var compatColumns = map[string]struct{}{
+	"window": {},
+}
+
+// TestColumnNames checks every column can be selected without quoting.
+func TestColumnNames(t *testing.T) {
+	db := memory.NewDatabase("kubernetes")
+	for _, provider := range Providers() {
+		db.AddTable(provider.Name, memory.NewTable(provider.Name, sql.NewPrimaryKeySchema(provider.Schema), nil))
+	}
+	engine := sqle.NewDefault(sql.NewDatabaseProvider(db))
+	ctx := sql.NewEmptyContext()
+	ctx.SetCurrentDatabase(db.Name())
+
+	for _, provider := range Providers() {
+		for _, column := range provider.Schema {
+			if _, ok := compatColumns[column.Name]; ok {
+				continue
+			}
+			query := fmt.Sprintf("SELECT %s FROM %s", column.Name, provider.Name)
+			if _, err := engine.AnalyzeQuery(ctx, query); err != nil {
+				t.Errorf("%s: %v", query, err)
+			}
+		}
+	}
+}
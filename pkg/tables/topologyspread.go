@@ -0,0 +1,301 @@
+package tables
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+func init() {
+	Register(TableProvider{
+		Name:     TopologySpreadTableName,
+		Resource: PodResource,
+		Schema: sql.Schema{
+			{Name: "pod_uid", Type: sql.Text, Nullable: false, Source: TopologySpreadTableName},
+			{Name: "pod", Type: sql.Text, Nullable: false, Source: TopologySpreadTableName},
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: TopologySpreadTableName},
+			{Name: "topology_key", Type: sql.Text, Nullable: false, Source: TopologySpreadTableName},
+			{Name: "max_skew", Type: sql.Int32, Nullable: false, Source: TopologySpreadTableName},
+			{Name: "when_unsatisfiable", Type: sql.Text, Nullable: false, Source: TopologySpreadTableName},
+			{Name: "label_selector", Type: sql.Text, Nullable: false, Source: TopologySpreadTableName},
+			{Name: "min_domains", Type: sql.Int32, Nullable: true, Source: TopologySpreadTableName},
+		},
+		Rows: topologySpreadRows,
+	})
+
+	Register(TableProvider{
+		Name:     TopologySkewTableName,
+		Resource: PodResource,
+		Schema: sql.Schema{
+			{Name: "namespace", Type: sql.Text, Nullable: false, Source: TopologySkewTableName},
+			{Name: "topology_key", Type: sql.Text, Nullable: false, Source: TopologySkewTableName},
+			{Name: "label_selector", Type: sql.Text, Nullable: false, Source: TopologySkewTableName},
+			{Name: "max_skew", Type: sql.Int32, Nullable: false, Source: TopologySkewTableName},
+			{Name: "constrained_pods", Type: sql.Int32, Nullable: false, Source: TopologySkewTableName},
+			{Name: "domain", Type: sql.Text, Nullable: false, Source: TopologySkewTableName},
+			{Name: "pods", Type: sql.Int32, Nullable: false, Source: TopologySkewTableName},
+			{Name: "skew", Type: sql.Int32, Nullable: false, Source: TopologySkewTableName},
+			{Name: "violated", Type: sql.Boolean, Nullable: false, Source: TopologySkewTableName},
+		},
+		Rows: topologySkewRows,
+		Objects: func(time.Duration) ObjectSource {
+			return topologySkewSource{}
+		},
+		Lookups: []Resource{NodeResource},
+	})
+}
+
+func topologySpreadRows(resource interface{}) ([]sql.Row, error) {
+	pod, ok := resource.(*v1.Pod)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *v1.Pod but got %T", resource)
+	}
+
+	rows := make([]sql.Row, 0, len(pod.Spec.TopologySpreadConstraints))
+	for _, constraint := range pod.Spec.TopologySpreadConstraints {
+		rows = append(rows, sql.NewRow(string(pod.UID), pod.Name, pod.Namespace,
+			constraint.TopologyKey,
+			constraint.MaxSkew,
+			string(constraint.WhenUnsatisfiable),
+			metav1.FormatLabelSelector(constraint.LabelSelector),
+			nullableInt32(constraint.MinDomains)))
+	}
+	return rows, nil
+}
+
+// topologySkewGroup gathers the pods of a namespace sharing a spread
+// constraint and the nodes they may run on, with the number of matching pods
+// running in every topology domain.
+type topologySkewGroup struct {
+	namespace  string
+	constraint v1.TopologySpreadConstraint
+	pods       int32
+	counts     map[string]int32
+}
+
+// topologySkewSource groups the pods with spread constraints when the table
+// is scanned, so the domains of pods sharing a constraint, such as the
+// replicas of a deployment, are counted once per scan. It reads the pod and
+// node caches and ignores the events it is subscribed to.
+type topologySkewSource struct {
+	cache.ResourceEventHandlerFuncs
+}
+
+func (topologySkewSource) List() []interface{} {
+	nodeStore, ok := LookupCache(NodeResource)
+	if !ok {
+		return nil
+	}
+	podStore, ok := LookupCache(PodResource)
+	if !ok {
+		return nil
+	}
+
+	pods := []*v1.Pod{}
+	counted := map[string][]*v1.Pod{}
+	for _, obj := range podStore.List() {
+		pod, ok := obj.(*v1.Pod)
+		if !ok {
+			continue
+		}
+		if len(pod.Spec.TopologySpreadConstraints) > 0 {
+			pods = append(pods, pod)
+		}
+		if countsForSpread(pod) {
+			counted[pod.Namespace] = append(counted[pod.Namespace], pod)
+		}
+	}
+	nodes := []*v1.Node{}
+	for _, obj := range nodeStore.List() {
+		if node, ok := obj.(*v1.Node); ok {
+			nodes = append(nodes, node)
+		}
+	}
+
+	groups := map[string]*topologySkewGroup{}
+	keys := []string{}
+	for _, pod := range pods {
+		for i := range pod.Spec.TopologySpreadConstraints {
+			constraint := &pod.Spec.TopologySpreadConstraints[i]
+			key, err := topologySkewKey(pod, constraint)
+			if err != nil {
+				log.WithError(err).Warnf("cannot group spread constraint of pod %s/%s", pod.Namespace, pod.Name)
+				continue
+			}
+			if group, ok := groups[key]; ok {
+				group.pods++
+				continue
+			}
+
+			counts, err := topologyDomainCounts(pod, constraint, nodes, counted[pod.Namespace])
+			if err != nil {
+				log.WithError(err).Warnf("cannot count spread domains of pod %s/%s", pod.Namespace, pod.Name)
+				continue
+			}
+			groups[key] = &topologySkewGroup{namespace: pod.Namespace, constraint: *constraint, pods: 1, counts: counts}
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+	objects := make([]interface{}, len(keys))
+	for i, key := range keys {
+		objects[i] = groups[key]
+	}
+	return objects
+}
+
+// topologySkewKey identifies the group of a constraint: its namespace, the
+// constraint itself and the node selection it depends on.
+func topologySkewKey(pod *v1.Pod, constraint *v1.TopologySpreadConstraint) (string, error) {
+	var required *v1.NodeSelector
+	if pod.Spec.Affinity != nil && pod.Spec.Affinity.NodeAffinity != nil {
+		required = pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	}
+	key, err := json.Marshal(struct {
+		Namespace    string
+		Constraint   *v1.TopologySpreadConstraint
+		NodeSelector map[string]string
+		Required     *v1.NodeSelector
+	}{pod.Namespace, constraint, pod.Spec.NodeSelector, required})
+	return string(key), err
+}
+
+// topologyDomainCounts counts the matching pods running in every topology
+// domain, following the scheduler: only nodes eligible for the pod make up
+// the domains.
+func topologyDomainCounts(pod *v1.Pod, constraint *v1.TopologySpreadConstraint, nodes []*v1.Node, pods []*v1.Pod) (map[string]int32, error) {
+	selector, err := metav1.LabelSelectorAsSelector(constraint.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+	eligible, err := eligibleNode(pod, constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeDomains := map[string]string{}
+	counts := map[string]int32{}
+	for _, node := range nodes {
+		domain, ok := node.Labels[constraint.TopologyKey]
+		if !ok || !eligible(node) {
+			continue
+		}
+		nodeDomains[node.Name] = domain
+		if _, ok := counts[domain]; !ok {
+			counts[domain] = 0
+		}
+	}
+	for _, other := range pods {
+		domain, ok := nodeDomains[other.Spec.NodeName]
+		if ok && selector.Matches(labels.Set(other.Labels)) {
+			counts[domain]++
+		}
+	}
+	return counts, nil
+}
+
+// topologySkewRows emits a row per topology domain of a group with its skew.
+// The global minimum is zero when fewer than minDomains domains exist.
+func topologySkewRows(obj interface{}) ([]sql.Row, error) {
+	group, ok := obj.(*topologySkewGroup)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for resource, expected *topologySkewGroup but got %T", obj)
+	}
+	constraint := group.constraint
+
+	minPods := int32(0)
+	if constraint.MinDomains == nil || int32(len(group.counts)) >= *constraint.MinDomains {
+		minPods = minCount(group.counts)
+	}
+
+	domains := make([]string, 0, len(group.counts))
+	for domain := range group.counts {
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+
+	rows := make([]sql.Row, 0, len(domains))
+	for _, domain := range domains {
+		skew := group.counts[domain] - minPods
+		rows = append(rows, sql.NewRow(group.namespace,
+			constraint.TopologyKey,
+			metav1.FormatLabelSelector(constraint.LabelSelector),
+			constraint.MaxSkew,
+			group.pods,
+			domain,
+			group.counts[domain],
+			skew,
+			boolValue(skew > constraint.MaxSkew)))
+	}
+	return rows, nil
+}
+
+// eligibleNode tells whether a node takes part in the spread of a pod. Node
+// affinity and nodeSelector are honored unless the constraint opts out.
+func eligibleNode(pod *v1.Pod, constraint *v1.TopologySpreadConstraint) (func(*v1.Node) bool, error) {
+	if constraint.NodeAffinityPolicy != nil && *constraint.NodeAffinityPolicy == v1.NodeInclusionPolicyIgnore {
+		return func(*v1.Node) bool { return true }, nil
+	}
+
+	nodeSelector := labels.SelectorFromSet(pod.Spec.NodeSelector)
+	type termSelector struct {
+		labels labels.Selector
+		fields fields.Selector
+	}
+	terms := []termSelector{}
+	for _, term := range nodeAffinityTerms(pod) {
+		if term.kind != requiredTerm {
+			continue
+		}
+		labelSelector, fieldSelector, err := nodeSelectorTermSelectors(&term.NodeSelectorTerm)
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, termSelector{labelSelector, fieldSelector})
+	}
+
+	return func(node *v1.Node) bool {
+		if !nodeSelector.Matches(labels.Set(node.Labels)) {
+			return false
+		}
+		if len(terms) == 0 {
+			return true
+		}
+		for _, term := range terms {
+			if term.labels.Matches(labels.Set(node.Labels)) && term.fields.Matches(fields.Set{"metadata.name": node.Name}) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// countsForSpread tells whether a pod is counted by the scheduler when
+// spreading: it must be bound to a node and neither terminated nor deleted.
+func countsForSpread(pod *v1.Pod) bool {
+	if pod.Spec.NodeName == "" || pod.DeletionTimestamp != nil {
+		return false
+	}
+	return pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed
+}
+
+func minCount(counts map[string]int32) int32 {
+	first := true
+	min := int32(0)
+	for _, count := range counts {
+		if first || count < min {
+			min = count
+			first = false
+		}
+	}
+	return min
+}
@@ -0,0 +1,102 @@
+package tables
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestTopologySkew(t *testing.T) {
+	zone := "topology.kubernetes.io/zone"
+	node := func(name, domain string) *v1.Node {
+		return &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{zone: domain}}}
+	}
+	pod := func(name, nodeName, app string, constraints ...v1.TopologySpreadConstraint) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": app}},
+			Spec:       v1.PodSpec{NodeName: nodeName, TopologySpreadConstraints: constraints},
+			Status:     v1.PodStatus{Phase: v1.PodRunning},
+		}
+	}
+	spread := func(app string, minDomains *int32) v1.TopologySpreadConstraint {
+		return v1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       zone,
+			WhenUnsatisfiable: v1.DoNotSchedule,
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+			MinDomains:        minDomains,
+		}
+	}
+	three := int32(3)
+
+	tests := []struct {
+		name  string
+		nodes []*v1.Node
+		pods  []*v1.Pod
+		want  []sql.Row
+	}{
+		{
+			name:  "replicas share a group",
+			nodes: []*v1.Node{node("n1", "a"), node("n2", "b"), node("n3", "c")},
+			pods: []*v1.Pod{
+				pod("web-1", "n1", "web", spread("web", nil)),
+				pod("web-2", "n1", "web", spread("web", nil)),
+				pod("web-3", "n1", "web", spread("web", nil)),
+				pod("web-4", "n2", "web", spread("web", nil)),
+				pod("db-1", "n3", "db"),
+			},
+			want: []sql.Row{
+				sql.NewRow("default", zone, "app=web", int32(1), int32(4), "a", int32(3), int32(3), int8(1)),
+				sql.NewRow("default", zone, "app=web", int32(1), int32(4), "b", int32(1), int32(1), int8(0)),
+				sql.NewRow("default", zone, "app=web", int32(1), int32(4), "c", int32(0), int32(0), int8(0)),
+			},
+		},
+		{
+			name:  "fewer domains than min domains",
+			nodes: []*v1.Node{node("n1", "a"), node("n2", "b")},
+			pods: []*v1.Pod{
+				pod("web-1", "n1", "web", spread("web", &three)),
+				pod("web-2", "n2", "web", spread("web", &three)),
+			},
+			want: []sql.Row{
+				sql.NewRow("default", zone, "app=web", int32(1), int32(2), "a", int32(1), int32(1), int8(0)),
+				sql.NewRow("default", zone, "app=web", int32(1), int32(2), "b", int32(1), int32(1), int8(0)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeStore := cache.NewStore(cache.MetaNamespaceKeyFunc)
+			for _, n := range tt.nodes {
+				if err := nodeStore.Add(n); err != nil {
+					t.Fatal(err)
+				}
+			}
+			podStore := cache.NewStore(cache.MetaNamespaceKeyFunc)
+			for _, p := range tt.pods {
+				if err := podStore.Add(p); err != nil {
+					t.Fatal(err)
+				}
+			}
+			setCache(NodeResource, nodeStore)
+			setCache(PodResource, podStore)
+
+			rows := []sql.Row{}
+			for _, group := range (topologySkewSource{}).List() {
+				groupRows, err := topologySkewRows(group)
+				if err != nil {
+					t.Fatal(err)
+				}
+				rows = append(rows, groupRows...)
+			}
+			if !reflect.DeepEqual(rows, tt.want) {
+				t.Errorf("rows = %v, want %v", rows, tt.want)
+			}
+		})
+	}
+}
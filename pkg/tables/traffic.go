@@ -60,42 +60,40 @@ var (
 	promURL = flag.String("promURL", "http://prometheus.istio-system:9090", "the URL of the Prometheus server -- http://localhost:9090")
 )
 
-func StartTrafficInformer(ctx context.Context, db *memory.Database) {
+func init() {
+	Register(TableProvider{
+		Name:   TrafficTableName,
+		Schema: trafficSchema,
+		Start:  startTrafficInformer,
+	})
+}
+
+func startTrafficInformer(ctx *sql.Context, db *memory.Database) {
 	defer runtime.HandleCrash()
 
 	d, err := time.ParseDuration(duration)
 	if err != nil {
-		log.Warn("cannot parse %s into duration, fallback to 5m", duration)
+		log.Warnf("cannot parse %s into duration, fallback to 5m", duration)
 		d = 5 * time.Minute
 	}
 
-	go func(ctx context.Context) {
-		initTrafficTable(db)
-		sqlCtx := sql.NewContext(ctx)
-		queryMetrics(sqlCtx)
+	trafficTable := newTrafficTable(db)
+	queryMetrics(ctx, trafficTable)
 
-		trafficTable := table(TrafficTableName)
-		if trafficTable == nil {
-			log.Fatalf("table %s does not exist", TrafficTableName)
-		}
+	for {
+		select {
+		case <-time.After(d):
+			trafficTable.Drop(ctx)
+			trafficTable.table = createTrafficTable(db)
+			queryMetrics(ctx, trafficTable)
+		case <-ctx.Done():
+			return
 
-		for {
-			select {
-			case <-time.After(d):
-				trafficTable.Drop(sqlCtx)
-				trafficTable.(*TrafficTable).table = createTrafficTable(db)
-				queryMetrics(sqlCtx)
-			case <-ctx.Done():
-				return
-
-			}
 		}
-
-	}(ctx)
-
+	}
 }
 
-func queryMetrics(ctx context.Context) {
+func queryMetrics(ctx context.Context, trafficTable *TrafficTable) {
 
 	queries := []string{
 		requestCountQuery,
@@ -177,10 +175,6 @@ func queryMetrics(ctx context.Context) {
 	}()
 
 	go func(ctx context.Context) {
-		trafficTable := table(TrafficTableName)
-		if trafficTable == nil {
-			log.Fatal("table %s does not exist", TrafficTableName)
-		}
 		for resp := range streamPromResp {
 			ctx := sql.NewContext(ctx)
 			err := trafficTable.Insert(ctx, resp)
@@ -315,31 +309,31 @@ type TrafficTable struct {
 	logger *logrus.Entry
 }
 
-func initTrafficTable(db *memory.Database) {
-	if _, ok := tables[TrafficTableName]; !ok {
-		tables[TrafficTableName] = &TrafficTable{
-			db:     db,
-			table:  createTrafficTable(db),
-			logger: tableLogger(PodMetricsTableName),
-		}
+var trafficSchema = sql.Schema{
+	{Name: "src_deployment", Type: sql.Text, Nullable: false, Source: TrafficTableName},
+	{Name: "src_namespace", Type: sql.Text, Nullable: false, Source: TrafficTableName},
+	{Name: "dst_deployment", Type: sql.Text, Nullable: false, Source: TrafficTableName},
+	{Name: "dst_pod", Type: sql.Text, Nullable: false, Source: TrafficTableName},
+	{Name: "dst_instance", Type: sql.Text, Nullable: false, Source: TrafficTableName},
+	{Name: "dst_service", Type: sql.Text, Nullable: false, Source: TrafficTableName},
+	{Name: "dst_namespace", Type: sql.Text, Nullable: false, Source: TrafficTableName},
+	{Name: "protocol", Type: sql.Text, Nullable: false, Source: TrafficTableName},
+	{Name: "http_status_code", Type: sql.Int32, Nullable: false, Source: TrafficTableName},
+	{Name: "grpc_status_code", Type: sql.Int32, Nullable: false, Source: TrafficTableName},
+	{Name: "metric", Type: sql.Text, Nullable: false, Source: TrafficTableName},
+	{Name: "value", Type: sql.Float64, Nullable: false, Source: TrafficTableName},
+}
+
+func newTrafficTable(db *memory.Database) *TrafficTable {
+	return &TrafficTable{
+		db:     db,
+		table:  createTrafficTable(db),
+		logger: tableLogger(TrafficTableName),
 	}
 }
 
 func createTrafficTable(db *memory.Database) *memory.Table {
-	table := memory.NewTable(TrafficTableName, sql.NewPrimaryKeySchema(sql.Schema{
-		{Name: "src_deployment", Type: sql.Text, Nullable: false, Source: TrafficTableName},
-		{Name: "src_namespace", Type: sql.Text, Nullable: false, Source: TrafficTableName},
-		{Name: "dst_deployment", Type: sql.Text, Nullable: false, Source: TrafficTableName},
-		{Name: "dst_pod", Type: sql.Text, Nullable: false, Source: TrafficTableName},
-		{Name: "dst_instance", Type: sql.Text, Nullable: false, Source: TrafficTableName},
-		{Name: "dst_service", Type: sql.Text, Nullable: false, Source: TrafficTableName},
-		{Name: "dst_namespace", Type: sql.Text, Nullable: false, Source: TrafficTableName},
-		{Name: "protocol", Type: sql.Text, Nullable: false, Source: TrafficTableName},
-		{Name: "http_status_code", Type: sql.Int32, Nullable: false, Source: TrafficTableName},
-		{Name: "grpc_status_code", Type: sql.Int32, Nullable: false, Source: TrafficTableName},
-		{Name: "metric", Type: sql.Text, Nullable: false, Source: TrafficTableName},
-		{Name: "value", Type: sql.Float64, Nullable: false, Source: TrafficTableName},
-	}), db.GetForeignKeyCollection())
+	table := memory.NewTable(TrafficTableName, sql.NewPrimaryKeySchema(trafficSchema), db.GetForeignKeyCollection())
 
 	db.AddTable(TrafficTableName, table)
 	log.Infof("table [%s] created", TrafficTableName)
@@ -379,11 +373,11 @@ func (t *TrafficTable) Insert(ctx *sql.Context, resource interface{}) error {
 }
 
 func (t *TrafficTable) Delete(ctx *sql.Context, resource interface{}) error {
-	t.Log().Warn("delete in table %s is not implemented", TrafficTableName)
+	t.Log().Warnf("delete in table %s is not implemented", TrafficTableName)
 	return nil
 }
 
 func (t *TrafficTable) Update(ctx *sql.Context, oldres, newres interface{}) error {
-	t.Log().Warn("update table %s is not implemented", TrafficTableName)
+	t.Log().Warnf("update table %s is not implemented", TrafficTableName)
 	return nil
 }
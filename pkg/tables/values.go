@@ -0,0 +1,50 @@
+package tables
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func boolValue(b bool) int8 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// nullableBool maps an unset condition to NULL, its state being unknown.
+func nullableBool(b *bool) interface{} {
+	if b == nil {
+		return nil
+	}
+	return boolValue(*b)
+}
+
+func nullableInt32(i *int32) interface{} {
+	if i == nil {
+		return nil
+	}
+	return *i
+}
+
+func nullableTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+func nullableMetaTime(t *metav1.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return nullableTime(t.Time)
+}